@@ -0,0 +1,16 @@
+// Command validatetag checks validate struct tags.
+//
+// It can be run on its own or through go vet:
+//
+//	go get -tool github.com/solrac97gr/validator/analysis/validatetag/cmd/validatetag
+//	go vet -vettool=$(go tool -n validatetag) ./...
+package main
+
+import (
+	"github.com/solrac97gr/validator/analysis/validatetag"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(validatetag.Analyzer)
+}
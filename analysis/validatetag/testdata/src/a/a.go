@@ -0,0 +1,62 @@
+package a
+
+import "time"
+
+type signup struct {
+	Email    string    `validate:"required,emial"` // want `unknown validation rule "emial"`
+	Age      int       `validate:"min=abc"`        // want `min expects a number, got "abc"`
+	Nickname string    `validate:"alpha=1"`        // want `alpha takes no parameter`
+	Password string    `validate:"required,min=8"`
+	Confirm  string    `validate:"eqfield=Pasword"` // want `rule "eqfield" compares with "Pasword", but signup has no field Pasword; did you mean Password\?`
+	Other    string    `validate:"nefield=Qwerty"`  // want `rule "nefield" compares with "Qwerty", but signup has no field Qwerty$`
+	Start    time.Time `validate:"required"`
+	End      time.Time `validate:"gtfield=Start"`
+	City     string    `validate:"nefield=Address.City"`
+	Zip      string    `validate:"eqfield=Address.Zip"` // want `rule "eqfield" compares with "Address.Zip", but address has no field Zip`
+	Street   string    `validate:"eqfield=Email.Host"`  // want `rule "eqfield" compares with "Email.Host", but string is not a struct`
+	Count    int       `validate:"email"`               // want `rule "email" cannot be applied to a field of type int`
+	Tags     []string  `validate:"create:requird"`      // want `unknown validation rule "requird"`
+	Name     string    `mod:"trim,lower"`
+	Title    string    `mod:"trimm"` // want `unknown mutator "trimm"`
+	Level    int       `mod:"trim"`  // want `mutators cannot be applied to a field of type int`
+	Aliases  []string  `mod:"trim"`
+	Extra    any       `validate:"required,email"`
+	Address  *address
+}
+
+func (s *signup) Validate(...interface{}) error { return nil }
+
+// address is checked through signup.
+type address struct {
+	City string `validate:"required"`
+}
+
+// draft is never checked: it is not evaluable nor nested in a struct that is.
+type draft struct { // want `draft has validate tags but does not implement validator.EvaluableStruct and is not nested in a struct that does`
+	Title string `validate:"required"`
+}
+
+// untagged structs are never reported.
+type untagged struct {
+	Title string
+}
+
+// Form may be nested by other packages, so it is not reported.
+type Form struct {
+	Title string `validate:"required"`
+	Body  body
+}
+
+// body is nested in an exported struct.
+type body struct {
+	Text string `validate:"required"`
+}
+
+// notValidate has a Validate method of the wrong signature.
+type notValidate struct { // want `notValidate has validate tags`
+	Title string `validate:"required"`
+}
+
+func (notValidate) Validate() error { return nil }
+
+var _, _, _, _ = draft{}, untagged{}, Form{}, notValidate{}
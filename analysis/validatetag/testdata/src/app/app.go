@@ -0,0 +1,17 @@
+package main
+
+// Request is exported, but no other package can nest the structs of a
+// main package.
+type Request struct { // want `Request has validate tags`
+	Name string `validate:"required"`
+}
+
+type checked struct {
+	Name string `validate:"required"`
+}
+
+func (c checked) Validate(...interface{}) error { return nil }
+
+func main() {
+	_, _ = Request{}, checked{}
+}
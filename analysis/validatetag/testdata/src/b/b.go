@@ -0,0 +1,23 @@
+package b
+
+// Form is reported with -exported, as are the structs only it nests.
+type Form struct { // want `Form has validate tags`
+	Title string `validate:"required,slug"`
+	Slug  string `mod:"slugify"`
+	Body  body
+}
+
+type body struct { // want `body has validate tags`
+	Text string `validate:"required"`
+}
+
+// Comment is checked through Post.
+type Comment struct {
+	Text string `validate:"required"`
+}
+
+type Post struct {
+	Comments []*Comment
+}
+
+func (p *Post) Validate(...interface{}) error { return nil }
@@ -0,0 +1,409 @@
+// Package validatetag defines an Analyzer that checks validate struct tags
+// at build time.
+//
+// It reports tags referencing unknown rules, rules given a bad parameter or
+// applied to a field of the wrong type, cross-field rules comparing with a
+// field the struct does not have, mod tags referencing unknown mutators or
+// applied to a field that is not a string, and structs with validate tags
+// that are never checked because they don't implement
+// validator.EvaluableStruct and aren't nested in a struct that does.
+//
+// Exported structs of packages other than main, and the structs nested in
+// them, are not reported as unchecked by default, since the analyzer
+// cannot see the packages that may nest them. The -exported flag reports
+// them too, for packages whose exported structs are only used locally.
+package validatetag
+
+import (
+	"fmt"
+	"go/ast"
+	"go/types"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/solrac97gr/validator"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+)
+
+const doc = `check validate struct tags
+
+Reports validate tags that reference unknown rules, give a rule a bad
+parameter or apply it to a field of the wrong type, cross-field rules
+that compare with a field the struct does not have, mod tags that
+reference unknown mutators or apply to a field that is not a string, and
+structs whose validate tags are never checked by a validator.Validator.
+Exported structs of packages other than main are assumed to be checked
+by the packages importing them, unless -exported is set.`
+
+// Analyzer checks validate struct tags.
+var Analyzer = &analysis.Analyzer{
+	Name:     "validatetag",
+	Doc:      doc,
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// customRules and customMutators list rules and mutators registered at
+// runtime with RegisterRule and RegisterMutator, which the analyzer cannot
+// see.
+var customRules, customMutators string
+
+// reportExported reports the unchecked exported structs of packages other
+// than main.
+var reportExported bool
+
+func init() {
+	Analyzer.Flags.StringVar(&customRules, "rules", "", "comma-separated names of custom rules registered at runtime")
+	Analyzer.Flags.StringVar(&customMutators, "mutators", "", "comma-separated names of custom mutators registered at runtime")
+	Analyzer.Flags.BoolVar(&reportExported, "exported", false, "also report unchecked exported structs of packages other than main")
+}
+
+// taggedStruct is a struct type declared in the package being analyzed.
+type taggedStruct struct {
+	spec     *ast.TypeSpec
+	named    *types.Named
+	tags     bool
+	children []*types.Named
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+	rules := make(map[string]validator.Rule)
+	for _, r := range validator.BuiltinRules() {
+		rules[r.Name] = r
+	}
+	custom := names(customRules)
+	mutators := names(customMutators)
+	for _, name := range validator.BuiltinMutators() {
+		mutators[name] = true
+	}
+
+	insp := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+	var structs []*taggedStruct
+
+	insp.Preorder([]ast.Node{(*ast.TypeSpec)(nil)}, func(n ast.Node) {
+		spec := n.(*ast.TypeSpec)
+		st, ok := spec.Type.(*ast.StructType)
+		if !ok {
+			return
+		}
+		ts := &taggedStruct{spec: spec}
+		if obj, ok := pass.TypesInfo.Defs[spec.Name].(*types.TypeName); ok {
+			ts.named, _ = obj.Type().(*types.Named)
+		}
+		var self types.Type = ts.named
+		if ts.named == nil {
+			self = pass.TypesInfo.TypeOf(spec.Type)
+		}
+		for _, field := range st.Fields.List {
+			ft := pass.TypesInfo.TypeOf(field.Type)
+			if named := structOf(ft); named != nil {
+				ts.children = append(ts.children, named)
+			}
+			if field.Tag == nil {
+				continue
+			}
+			tagValue, err := strconv.Unquote(field.Tag.Value)
+			if err != nil {
+				continue
+			}
+			if mod, ok := reflect.StructTag(tagValue).Lookup(validator.ModTagName); ok {
+				checkMod(pass, field, ft, mod, mutators)
+			}
+			tag, ok := reflect.StructTag(tagValue).Lookup(validator.TagName)
+			if !ok {
+				continue
+			}
+			ts.tags = true
+			checkTag(pass, field, ft, self, tag, rules, custom)
+		}
+		structs = append(structs, ts)
+	})
+
+	checked := checkedStructs(structs, pass.Pkg.Name() != "main" && !reportExported)
+	for _, ts := range structs {
+		if !ts.tags || ts.named == nil || checked[ts.named] {
+			continue
+		}
+		pass.Reportf(ts.spec.Name.Pos(), "%s has validate tags but does not implement validator.EvaluableStruct and is not nested in a struct that does", ts.spec.Name.Name)
+	}
+	return nil, nil
+}
+
+// names returns the set of names in a comma-separated list.
+func names(list string) map[string]bool {
+	set := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			set[name] = true
+		}
+	}
+	return set
+}
+
+// checkedStructs returns the structs checked by a validator: the ones
+// implementing validator.EvaluableStruct and the ones nested in them. When
+// exported is set, exported structs, which other packages may nest in
+// theirs, and the ones nested in them are assumed to be checked too.
+func checkedStructs(structs []*taggedStruct, exported bool) map[*types.Named]bool {
+	children := make(map[*types.Named][]*types.Named)
+	var queue []*types.Named
+	for _, ts := range structs {
+		if ts.named == nil {
+			continue
+		}
+		children[ts.named] = ts.children
+		if evaluable(ts.named) || exported && ts.named.Obj().Exported() {
+			queue = append(queue, ts.named)
+		}
+	}
+	checked := make(map[*types.Named]bool)
+	for len(queue) > 0 {
+		named := queue[0]
+		queue = queue[1:]
+		if checked[named] {
+			continue
+		}
+		checked[named] = true
+		queue = append(queue, children[named]...)
+	}
+	return checked
+}
+
+// checkTag checks the rules of every group of a validate tag against the
+// type of its field and the struct st declaring it.
+func checkTag(pass *analysis.Pass, field *ast.Field, ft, st types.Type, tag string, rules map[string]validator.Rule, custom map[string]bool) {
+	groups := validator.ParseTagGroups(tag)
+	names := make([]string, 0, len(groups))
+	for name := range groups {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		checkRules(pass, field, ft, st, groups[name], rules, custom)
+	}
+}
+
+// checkRules checks a list of rules against the type of their field and
+// the struct st declaring it.
+func checkRules(pass *analysis.Pass, field *ast.Field, ft, st types.Type, list []validator.TagRule, rules map[string]validator.Rule, custom map[string]bool) {
+	kind, known := kindOf(ft)
+	for _, tr := range list {
+		if custom[tr.Name] {
+			continue
+		}
+		r, ok := rules[tr.Name]
+		if !ok {
+			pass.Reportf(field.Tag.Pos(), "unknown validation rule %q", tr.Name)
+			continue
+		}
+		if err := r.CheckParam(tr.Param); err != nil {
+			pass.Reportf(field.Tag.Pos(), "%v", err)
+			continue
+		}
+		if r.Param == validator.ParamField {
+			if err := checkFieldPath(pass.Pkg, st, tr.Param); err != nil {
+				pass.Reportf(field.Tag.Pos(), "rule %q compares with %v", tr.Name, err)
+				continue
+			}
+		}
+		if known && !r.AppliesTo(kind) {
+			pass.Reportf(field.Tag.Pos(), "rule %q cannot be applied to a field of type %s", tr.Name, ft)
+		}
+	}
+}
+
+// checkFieldPath checks that the dotted path names a field of the struct
+// st, looking through pointers and embedded structs as the validator does.
+func checkFieldPath(pkg *types.Package, st types.Type, path string) error {
+	t := st
+	qualifier := types.RelativeTo(pkg)
+	for _, name := range strings.Split(path, ".") {
+		for {
+			p, ok := t.Underlying().(*types.Pointer)
+			if !ok {
+				break
+			}
+			t = p.Elem()
+		}
+		s, ok := t.Underlying().(*types.Struct)
+		if !ok {
+			return fmt.Errorf("%q, but %s is not a struct", path, types.TypeString(t, qualifier))
+		}
+		obj, _, _ := types.LookupFieldOrMethod(t, true, pkg, name)
+		f, ok := obj.(*types.Var)
+		if !ok || !f.IsField() {
+			if guess := closestField(s, name); guess != "" {
+				return fmt.Errorf("%q, but %s has no field %s; did you mean %s?", path, types.TypeString(t, qualifier), name, guess)
+			}
+			return fmt.Errorf("%q, but %s has no field %s", path, types.TypeString(t, qualifier), name)
+		}
+		t = f.Type()
+	}
+	return nil
+}
+
+// closestField returns the name of the field of s closest to name, if one
+// is at most two edits away.
+func closestField(s *types.Struct, name string) string {
+	best, bestDist := "", 3
+	for i := 0; i < s.NumFields(); i++ {
+		f := s.Field(i).Name()
+		if d := editDistance(strings.ToLower(f), strings.ToLower(name)); d < bestDist {
+			best, bestDist = f, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// checkMod checks the mutators of a mod tag and the type of their field,
+// which must hold strings.
+func checkMod(pass *analysis.Pass, field *ast.Field, ft types.Type, tag string, mutators map[string]bool) {
+	for _, name := range strings.Split(tag, ",") {
+		if name = strings.TrimSpace(name); name != "" && !mutators[name] {
+			pass.Reportf(field.Tag.Pos(), "unknown mutator %q", name)
+		}
+	}
+	kind, known := kindOf(ft)
+	switch {
+	case !known || kind == reflect.String:
+		return
+	case kind == reflect.Slice || kind == reflect.Array:
+		if b, ok := elemOf(ft).Underlying().(*types.Basic); ok && b.Kind() == types.String {
+			return
+		}
+	}
+	pass.Reportf(field.Tag.Pos(), "mutators cannot be applied to a field of type %s", ft)
+}
+
+// elemOf returns the element type of the slice or array held by t, looking
+// through pointers.
+func elemOf(t types.Type) types.Type {
+	for {
+		switch u := t.Underlying().(type) {
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			return u.Elem()
+		case *types.Array:
+			return u.Elem()
+		default:
+			return t
+		}
+	}
+}
+
+// kindOf returns the reflect.Kind of values of type t once pointers are
+// dereferenced, as seen by the validator.
+func kindOf(t types.Type) (reflect.Kind, bool) {
+	for {
+		p, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			break
+		}
+		t = p.Elem()
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		kind, ok := basicKinds[u.Kind()]
+		return kind, ok
+	case *types.Slice:
+		return reflect.Slice, true
+	case *types.Array:
+		return reflect.Array, true
+	case *types.Map:
+		return reflect.Map, true
+	case *types.Struct:
+		return reflect.Struct, true
+	case *types.Chan:
+		return reflect.Chan, true
+	case *types.Signature:
+		return reflect.Func, true
+	}
+	// Interfaces hold values of any kind.
+	return reflect.Invalid, false
+}
+
+var basicKinds = map[types.BasicKind]reflect.Kind{
+	types.Bool:       reflect.Bool,
+	types.Int:        reflect.Int,
+	types.Int8:       reflect.Int8,
+	types.Int16:      reflect.Int16,
+	types.Int32:      reflect.Int32,
+	types.Int64:      reflect.Int64,
+	types.Uint:       reflect.Uint,
+	types.Uint8:      reflect.Uint8,
+	types.Uint16:     reflect.Uint16,
+	types.Uint32:     reflect.Uint32,
+	types.Uint64:     reflect.Uint64,
+	types.Uintptr:    reflect.Uintptr,
+	types.Float32:    reflect.Float32,
+	types.Float64:    reflect.Float64,
+	types.Complex64:  reflect.Complex64,
+	types.Complex128: reflect.Complex128,
+	types.String:     reflect.String,
+}
+
+// structOf returns the named struct type held by t, looking through
+// pointers, slices, arrays and maps.
+func structOf(t types.Type) *types.Named {
+	for t != nil {
+		switch u := t.(type) {
+		case *types.Named:
+			if _, ok := u.Underlying().(*types.Struct); ok {
+				return u
+			}
+			t = u.Underlying()
+		case *types.Pointer:
+			t = u.Elem()
+		case *types.Slice:
+			t = u.Elem()
+		case *types.Array:
+			t = u.Elem()
+		case *types.Map:
+			t = u.Elem()
+		default:
+			return nil
+		}
+	}
+	return nil
+}
+
+// evaluable reports whether named or a pointer to it implements
+// validator.EvaluableStruct.
+func evaluable(named *types.Named) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), true, named.Obj().Pkg(), "Validate")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if !sig.Variadic() || sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	return types.Identical(sig.Results().At(0).Type(), types.Universe.Lookup("error").Type())
+}
@@ -0,0 +1,28 @@
+package validatetag
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "a", "app")
+}
+
+func TestAnalyzerFlags(t *testing.T) {
+	setFlag(t, "rules", "slug")
+	setFlag(t, "mutators", "slugify")
+	setFlag(t, "exported", "true")
+	analysistest.Run(t, analysistest.TestData(), Analyzer, "b")
+}
+
+// setFlag sets a flag of the analyzer for the duration of the test.
+func setFlag(t *testing.T, name, value string) {
+	t.Helper()
+	old := Analyzer.Flags.Lookup(name).Value.String()
+	if err := Analyzer.Flags.Set(name, value); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { Analyzer.Flags.Set(name, old) })
+}
@@ -0,0 +1,213 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	// ErrNilRecord is returned for nil records of a batch.
+	ErrNilRecord = errors.New("record is nil")
+	// ErrPanic is returned for records whose validation panicked.
+	ErrPanic = errors.New("validation panicked")
+)
+
+// Result is the outcome of validating a record of a batch.
+type Result struct {
+	// Index is the position of the record in the slice, or the order in
+	// which it was received from the channel.
+	Index int
+	// Err is the error returned by the validator, nil for valid records.
+	// It wraps ErrNilRecord for nil records and ErrPanic when the
+	// validation panicked.
+	Err error
+}
+
+// BatchStats reports the progress of a BatchValidator.
+type BatchStats struct {
+	// Validated is the number of records validated so far.
+	Validated int64
+	// Failed is the number of records that failed validation.
+	Failed int64
+	// Elapsed is the time spent validating, from the start of the first
+	// batch to the end of the last one or to now while one is running.
+	Elapsed time.Duration
+}
+
+// Throughput returns the number of records validated per second.
+func (s BatchStats) Throughput() float64 {
+	if s.Elapsed <= 0 {
+		return 0
+	}
+	return float64(s.Validated) / s.Elapsed.Seconds()
+}
+
+// BatchValidator validates large batches of structs with a bounded pool of
+// workers. It is safe to run several batches at once; their statistics
+// are added up.
+type BatchValidator struct {
+	val       Validator
+	workers   int
+	validated atomic.Int64
+	failed    atomic.Int64
+
+	mu      sync.Mutex
+	running int
+	start   time.Time
+	elapsed time.Duration
+}
+
+// NewBatchValidator returns a BatchValidator validating records with val
+// using at most workers goroutines. Zero or fewer workers means one per
+// CPU.
+func NewBatchValidator(val Validator, workers int) *BatchValidator {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	return &BatchValidator{val: val, workers: workers}
+}
+
+// Stream validates the records received from in until it is closed or ctx
+// is done. A Result is sent for every record, in no particular order, and
+// the returned channel is closed once all workers are done. The caller
+// must drain it.
+func (b *BatchValidator) Stream(ctx context.Context, in <-chan EvaluableStruct) <-chan Result {
+	index := 0
+	return b.run(ctx, func() (int, EvaluableStruct, bool) {
+		select {
+		case <-ctx.Done():
+			return 0, nil, false
+		case s, ok := <-in:
+			index++
+			return index - 1, s, ok
+		}
+	})
+}
+
+// Stats returns the statistics of the batches validated so far.
+func (b *BatchValidator) Stats() BatchStats {
+	b.mu.Lock()
+	elapsed := b.elapsed
+	if b.running > 0 {
+		elapsed += time.Since(b.start)
+	}
+	b.mu.Unlock()
+	return BatchStats{Validated: b.validated.Load(), Failed: b.failed.Load(), Elapsed: elapsed}
+}
+
+// ValidateSlice validates records with b, as Stream does. Result.Index is
+// the index of the record in records.
+//
+//	for res := range validator.ValidateSlice(ctx, batch, users) {
+//		if res.Err != nil {
+//			log.Printf("record %d: %v", res.Index, res.Err)
+//		}
+//	}
+func ValidateSlice[T EvaluableStruct](ctx context.Context, b *BatchValidator, records []T) <-chan Result {
+	index := 0
+	return b.run(ctx, func() (int, EvaluableStruct, bool) {
+		if index >= len(records) || ctx.Err() != nil {
+			return 0, nil, false
+		}
+		index++
+		return index - 1, records[index-1], true
+	})
+}
+
+// job is a record waiting for a worker.
+type job struct {
+	index int
+	s     EvaluableStruct
+}
+
+// run validates the records returned by next until it reports there are
+// no more.
+func (b *BatchValidator) run(ctx context.Context, next func() (int, EvaluableStruct, bool)) <-chan Result {
+	jobs := make(chan job)
+	out := make(chan Result, b.workers)
+	b.begin()
+
+	go func() {
+		defer close(jobs)
+		for {
+			i, s, ok := next()
+			if !ok {
+				return
+			}
+			select {
+			case jobs <- job{index: i, s: s}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < b.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				err := b.validate(j.s)
+				b.validated.Add(1)
+				if err != nil {
+					b.failed.Add(1)
+				}
+				select {
+				case out <- Result{Index: j.index, Err: err}:
+				case <-ctx.Done():
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		b.end()
+		close(out)
+	}()
+	return out
+}
+
+// validate validates a record, turning nil records and panics into
+// errors so that a bad record doesn't stop the batch.
+func (b *BatchValidator) validate(s EvaluableStruct) (err error) {
+	if s == nil {
+		return ErrNilRecord
+	}
+	if rv := reflect.ValueOf(s); rv.Kind() == reflect.Ptr && rv.IsNil() {
+		return fmt.Errorf("%w: %T", ErrNilRecord, s)
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrPanic, r)
+		}
+	}()
+	return b.val.Struct(s)
+}
+
+// begin records the start of a batch.
+func (b *BatchValidator) begin() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.running == 0 {
+		b.start = time.Now()
+	}
+	b.running++
+}
+
+// end records the end of a batch.
+func (b *BatchValidator) end() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.running--
+	if b.running == 0 {
+		b.elapsed += time.Since(b.start)
+	}
+}
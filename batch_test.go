@@ -0,0 +1,193 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// record is validated by the batch tests. Its Validate method panics when
+// panics is set.
+type record struct {
+	Name   string `validate:"required"`
+	panics bool
+}
+
+func (r *record) Validate(...interface{}) error {
+	if r.panics {
+		panic("boom")
+	}
+	return nil
+}
+
+// records returns n records, every third of which is invalid.
+func records(n int) []*record {
+	rs := make([]*record, n)
+	for i := range rs {
+		rs[i] = &record{Name: "name"}
+		if i%3 == 0 {
+			rs[i].Name = ""
+		}
+	}
+	return rs
+}
+
+// collect drains results, failing the test if it is not closed in time.
+func collect(t *testing.T, results <-chan Result) []Result {
+	t.Helper()
+	var all []Result
+	timeout := time.After(10 * time.Second)
+	for {
+		select {
+		case res, ok := <-results:
+			if !ok {
+				return all
+			}
+			all = append(all, res)
+		case <-timeout:
+			t.Fatal("result channel was not closed")
+		}
+	}
+}
+
+func TestValidateSlice(t *testing.T) {
+	b := NewBatchValidator(NewValidator(), 4)
+	rs := records(100)
+	seen := make(map[int]bool)
+	for _, res := range collect(t, ValidateSlice(context.Background(), b, rs)) {
+		if seen[res.Index] {
+			t.Errorf("record %d reported twice", res.Index)
+		}
+		seen[res.Index] = true
+		if invalid := res.Index%3 == 0; (res.Err != nil) != invalid {
+			t.Errorf("record %d: err = %v, want invalid %v", res.Index, res.Err, invalid)
+		}
+	}
+	if len(seen) != len(rs) {
+		t.Errorf("got %d results, want %d", len(seen), len(rs))
+	}
+	stats := b.Stats()
+	if stats.Validated != 100 || stats.Failed != 34 {
+		t.Errorf("Stats() = %+v, want 100 validated and 34 failed", stats)
+	}
+}
+
+func TestStream(t *testing.T) {
+	b := NewBatchValidator(NewValidator(), 2)
+	in := make(chan EvaluableStruct)
+	go func() {
+		defer close(in)
+		in <- &record{Name: "valid"}
+		in <- nil
+		in <- (*record)(nil)
+		in <- &record{Name: "panics", panics: true}
+		in <- &record{}
+	}()
+
+	want := []error{nil, ErrNilRecord, ErrNilRecord, ErrPanic, ErrRequired}
+	results := collect(t, b.Stream(context.Background(), in))
+	if len(results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(results), len(want))
+	}
+	for _, res := range results {
+		switch {
+		case want[res.Index] == nil && res.Err != nil:
+			t.Errorf("record %d: unexpected error %v", res.Index, res.Err)
+		case want[res.Index] == ErrRequired:
+			var errs ValidationErrors
+			if !errors.As(res.Err, &errs) || len(errs) != 1 || errs[0].Err != ErrRequired {
+				t.Errorf("record %d: err = %v, want %v", res.Index, res.Err, ErrRequired)
+			}
+		case want[res.Index] != nil && !errors.Is(res.Err, want[res.Index]):
+			t.Errorf("record %d: err = %v, want %v", res.Index, res.Err, want[res.Index])
+		}
+	}
+}
+
+func TestStreamCancel(t *testing.T) {
+	b := NewBatchValidator(NewValidator(), 2)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	in := make(chan EvaluableStruct)
+	// Feed records until the batch stops reading them.
+	go func() {
+		for {
+			select {
+			case in <- &record{Name: "name"}:
+			case <-time.After(time.Second):
+				return
+			}
+		}
+	}()
+
+	results := b.Stream(ctx, in)
+	for i := 0; i < 10; i++ {
+		<-results
+	}
+	cancel()
+	collect(t, results)
+	if stats := b.Stats(); stats.Validated < 10 {
+		t.Errorf("Stats().Validated = %d, want at least 10", stats.Validated)
+	}
+}
+
+func TestValidateSliceCancel(t *testing.T) {
+	b := NewBatchValidator(NewValidator(), 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	rs := records(10000)
+	results := ValidateSlice(ctx, b, rs)
+	<-results
+	cancel()
+	if n := len(collect(t, results)) + 1; n == len(rs) {
+		t.Errorf("got all %d results after cancellation", n)
+	}
+}
+
+func TestConcurrentBatches(t *testing.T) {
+	b := NewBatchValidator(NewValidator(), 3)
+	ctx := context.Background()
+	done := make(chan struct{})
+
+	// Read the statistics while the batches run.
+	var readers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+					if stats := b.Stats(); stats.Failed > stats.Validated {
+						t.Errorf("Stats() = %+v, more failed than validated", stats)
+					}
+				}
+			}
+		}()
+	}
+
+	var batches sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		batches.Add(1)
+		go func() {
+			defer batches.Done()
+			if n := len(collect(t, ValidateSlice(ctx, b, records(300)))); n != 300 {
+				t.Errorf("got %d results, want 300", n)
+			}
+		}()
+	}
+	batches.Wait()
+	close(done)
+	readers.Wait()
+
+	stats := b.Stats()
+	if stats.Validated != 1500 || stats.Failed != 500 {
+		t.Errorf("Stats() = %+v, want 1500 validated and 500 failed", stats)
+	}
+	if stats.Elapsed <= 0 || stats.Throughput() <= 0 {
+		t.Errorf("Stats() = %+v, want a positive elapsed time and throughput", stats)
+	}
+}
@@ -0,0 +1,391 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/solrac97gr/validator/validations"
+)
+
+var (
+	// ErrMin is returned when a value or its length is below the minimum.
+	ErrMin = errors.New("value must be at least")
+	// ErrMax is returned when a value or its length is above the maximum.
+	ErrMax = errors.New("value must be at most")
+	// ErrLen is returned when a value does not have the exact length.
+	ErrLen = errors.New("value must have length")
+	// ErrEq is returned when a value is not equal to the parameter.
+	ErrEq = errors.New("value must be equal to")
+	// ErrNe is returned when a value is equal to the parameter.
+	ErrNe = errors.New("value must not be equal to")
+	// ErrGt is returned when a value is not greater than the parameter.
+	ErrGt = errors.New("value must be greater than")
+	// ErrLt is returned when a value is not less than the parameter.
+	ErrLt = errors.New("value must be less than")
+	// ErrOneOf is returned when a value is not one of the allowed values.
+	ErrOneOf = errors.New("value must be one of")
+	// ErrNotUnique is returned when a slice contains duplicated elements.
+	ErrNotUnique = errors.New("values must be unique")
+	// ErrNotEven is returned when a number is not even.
+	ErrNotEven = errors.New("value must be even")
+	// ErrNotOdd is returned when a number is not odd.
+	ErrNotOdd = errors.New("value must be odd")
+	// ErrNotPositive is returned when a number is not positive.
+	ErrNotPositive = errors.New("value must be positive")
+	// ErrNotNegative is returned when a number is not negative.
+	ErrNotNegative = errors.New("value must be negative")
+	// ErrNotMultipleOf is returned when a number is not a multiple of the parameter.
+	ErrNotMultipleOf = errors.New("value must be a multiple of")
+	// ErrNotDefault is returned when a value that must be left empty is set.
+	ErrNotDefault = errors.New("value must be empty")
+)
+
+var (
+	stringKinds = []reflect.Kind{reflect.String}
+	intKinds    = []reflect.Kind{
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+	}
+	numberKinds = append(append([]reflect.Kind{}, intKinds...), reflect.Float32, reflect.Float64)
+	sizeKinds   = append([]reflect.Kind{reflect.String, reflect.Slice, reflect.Array, reflect.Map}, numberKinds...)
+	portKinds   = append([]reflect.Kind{reflect.String}, intKinds...)
+)
+
+var builtinRules = map[string]Rule{}
+
+func init() {
+	for _, r := range []Rule{
+		{Name: "required", Func: required},
+		{Name: "isdefault", Func: isDefault},
+		{Name: "omitempty", Func: func(reflect.Value, string) error { return nil }},
+
+		{Name: "min", Func: compareRule(ErrMin, func(c int) bool { return c >= 0 }), Kinds: sizeKinds, Param: ParamNumber},
+		{Name: "max", Func: compareRule(ErrMax, func(c int) bool { return c <= 0 }), Kinds: sizeKinds, Param: ParamNumber},
+		{Name: "len", Func: compareRule(ErrLen, func(c int) bool { return c == 0 }), Kinds: sizeKinds, Param: ParamNumber},
+		{Name: "eq", Func: compareRule(ErrEq, func(c int) bool { return c == 0 }), Kinds: numberKinds, Param: ParamNumber},
+		{Name: "ne", Func: compareRule(ErrNe, func(c int) bool { return c != 0 }), Kinds: numberKinds, Param: ParamNumber},
+		{Name: "gt", Func: compareRule(ErrGt, func(c int) bool { return c > 0 }), Kinds: sizeKinds, Param: ParamNumber},
+		{Name: "gte", Func: compareRule(ErrMin, func(c int) bool { return c >= 0 }), Kinds: sizeKinds, Param: ParamNumber},
+		{Name: "lt", Func: compareRule(ErrLt, func(c int) bool { return c < 0 }), Kinds: sizeKinds, Param: ParamNumber},
+		{Name: "lte", Func: compareRule(ErrMax, func(c int) bool { return c <= 0 }), Kinds: sizeKinds, Param: ParamNumber},
+		{Name: "oneof", Func: oneOf, Kinds: append([]reflect.Kind{reflect.String}, numberKinds...), Param: ParamList},
+		{Name: "unique", Func: unique, Kinds: []reflect.Kind{reflect.Slice, reflect.Array}},
+		{Name: "eqfield", Func: needsOther, Kinds: crossKinds, Param: ParamField},
+		{Name: "nefield", Func: needsOther, Kinds: crossKinds, Param: ParamField},
+		{Name: "gtfield", Func: needsOther, Kinds: crossKinds, Param: ParamField},
+		{Name: "gtefield", Func: needsOther, Kinds: crossKinds, Param: ParamField},
+		{Name: "ltfield", Func: needsOther, Kinds: crossKinds, Param: ParamField},
+		{Name: "ltefield", Func: needsOther, Kinds: crossKinds, Param: ParamField},
+
+		{Name: "even", Func: intRule(validations.IsEven[int], ErrNotEven), Kinds: intKinds},
+		{Name: "odd", Func: intRule(validations.IsOdd[int], ErrNotOdd), Kinds: intKinds},
+		{Name: "positive", Func: compareRule(ErrNotPositive, func(c int) bool { return c > 0 }, "0"), Kinds: numberKinds},
+		{Name: "negative", Func: compareRule(ErrNotNegative, func(c int) bool { return c < 0 }, "0"), Kinds: numberKinds},
+		{Name: "multipleof", Func: multipleOf, Kinds: intKinds, Param: ParamNumber},
+
+		{Name: "alpha", Func: stringRule(validations.StringIsAlpha)},
+		{Name: "alphanum", Func: stringRule(validations.StringIsAlphanumeric)},
+		{Name: "alphaunicode", Func: stringRule(validations.StringIsAlphaUnicode)},
+		{Name: "alphanumunicode", Func: stringRule(validations.StringIsAlphanumericUnicode)},
+		{Name: "ascii", Func: stringRule(validations.StringIsASCIICode)},
+		{Name: "printascii", Func: stringRule(validations.StringIsPrintableASCII)},
+		{Name: "boolean", Func: stringRule(validations.StringIsBoolean)},
+		{Name: "numeric", Func: stringRule(validations.StringIsNumeric)},
+		{Name: "lowercase", Func: stringRule(validations.StringIsLowerCase)},
+		{Name: "uppercase", Func: stringRule(validations.StringIsUpperCase)},
+		{Name: "multibyte", Func: stringRule(validations.StringIsMultibyte)},
+		{Name: "contains", Func: stringParamRule(validations.StringContains), Param: ParamString},
+		{Name: "containsany", Func: stringListRule(validations.StringContainsAny), Param: ParamList},
+		{Name: "excludes", Func: stringParamRule(validations.StringExcludes), Param: ParamString},
+		{Name: "excludesall", Func: stringListRule(validations.StringExcludesAll), Param: ParamList},
+		{Name: "startswith", Func: stringParamRule(validations.StringStartsWith), Param: ParamString},
+		{Name: "startsnotwith", Func: stringParamRule(validations.StringStartsNotWith), Param: ParamString},
+		{Name: "endswith", Func: stringParamRule(validations.StringEndsWith), Param: ParamString},
+		{Name: "endsnotwith", Func: stringParamRule(validations.StringEndsNotWith), Param: ParamString},
+
+		{Name: "base64", Func: stringRule(validations.IsBase64)},
+		{Name: "base64url", Func: stringRule(validations.IsBase64URL)},
+		{Name: "base64rawurl", Func: stringRule(validations.IsBase64RawURL)},
+		{Name: "bic", Func: stringRule(validations.IsBIC)},
+		{Name: "bcp47_language_tag", Func: stringRule(validations.IsBCP47LanguageTag)},
+		{Name: "btc_addr", Func: stringRule(validations.IsBTCAddress)},
+		{Name: "credit_card", Func: stringRule(validations.IsValidCreditCard)},
+		{Name: "mongodb", Func: stringRule(validations.IsValidMongoID)},
+		{Name: "cron", Func: stringRule(validations.IsValidCron)},
+		{Name: "datetime", Func: stringRule(validations.IsValidDatetime)},
+		{Name: "e164", Func: stringRule(validations.IsValidE164PhoneNumber)},
+		{Name: "email", Func: stringRule(validations.IsValidEmail)},
+		{Name: "eth_addr", Func: stringRule(func(s string) error {
+			if !validations.IsEthAddress(s) {
+				return validations.ErrInvalidEthAddress
+			}
+			return nil
+		})},
+
+		{Name: "ip", Func: stringRule(validations.ValidateIPAddress)},
+		{Name: "ipv4", Func: stringRule(validations.ValidateIPv4Address)},
+		{Name: "ipv6", Func: stringRule(validations.ValidateIPv6Address)},
+		{Name: "hostname", Func: stringRule(validations.ValidateHostname)},
+		{Name: "hostname_rfc952", Func: stringRule(validations.ValidateRFC952)},
+		{Name: "fqdn", Func: stringRule(validations.ValidateFQDN)},
+		{Name: "idn_hostname", Func: stringRule(validations.ValidateUnicodeHostname)},
+		{Name: "punycode_hostname", Func: stringRule(validations.ValidatePunycodeHostname)},
+		{Name: "registrable_domain", Func: stringRule(validations.ValidateRegistrableDomain)},
+		{Name: "not_public_suffix", Func: stringRule(validations.ValidateNotPublicSuffix)},
+		{Name: "mac", Func: stringRule(validations.ValidateMACAddress)},
+		{Name: "mac48", Func: stringRule(validations.ValidateMAC48)},
+		{Name: "eui64", Func: stringRule(validations.ValidateEUI64)},
+		{Name: "unicast_mac", Func: stringRule(validations.ValidateUnicastMAC)},
+		{Name: "multicast_mac", Func: stringRule(validations.ValidateMulticastMAC)},
+		{Name: "universal_mac", Func: stringRule(validations.ValidateUniversalMAC)},
+		{Name: "local_mac", Func: stringRule(validations.ValidateLocalMAC)},
+		{Name: "cidrv4", Func: stringRule(validations.ValidateCIDRv4)},
+		{Name: "cidrv6", Func: stringRule(validations.ValidateCIDRv6)},
+		{Name: "cidr", Func: stringRule(validations.ValidateCIDR)},
+		{Name: "in_cidr", Func: stringListRule(validations.ValidateInCIDRs), Param: ParamList},
+		{Name: "cidr_no_overlap", Func: stringSliceRule(validations.ValidateNoOverlap), Kinds: []reflect.Kind{reflect.Slice, reflect.Array}},
+		{Name: "min_prefix", Func: prefixRule(true), Param: ParamNumber},
+		{Name: "max_prefix", Func: prefixRule(false), Param: ParamNumber},
+		{Name: "datauri", Func: stringRule(validations.ValidateDataURL)},
+		{Name: "tcp4_addr", Func: stringRule(validations.ValidateTCP4Addr)},
+		{Name: "tcp6_addr", Func: stringRule(validations.ValidateTCP6Addr)},
+		{Name: "tcp_addr", Func: stringRule(validations.ValidateTCPAddr)},
+		{Name: "hostname_port", Func: stringRule(validations.ValidateHostnamePort)},
+		{Name: "ip_port", Func: stringRule(validations.ValidateIPPort)},
+		{Name: "private_ip", Func: stringRule(validations.ValidatePrivateIP)},
+		{Name: "loopback_ip", Func: stringRule(validations.ValidateLoopbackIP)},
+		{Name: "link_local_ip", Func: stringRule(validations.ValidateLinkLocalIP)},
+		{Name: "multicast_ip", Func: stringRule(validations.ValidateMulticastIP)},
+		{Name: "unspecified_ip", Func: stringRule(validations.ValidateUnspecifiedIP)},
+		{Name: "documentation_ip", Func: stringRule(validations.ValidateDocumentationIP)},
+		{Name: "cgnat_ip", Func: stringRule(validations.ValidateCGNATIP)},
+		{Name: "public_ip", Func: stringRule(validations.ValidatePublicIP)},
+		{Name: "udp4_addr", Func: stringRule(validations.ValidateUDP4Addr)},
+		{Name: "udp6_addr", Func: stringRule(validations.ValidateUDP6Addr)},
+		{Name: "udp_addr", Func: stringRule(validations.ValidateUDPAddr)},
+		{Name: "unix_addr", Func: stringRule(validations.ValidateUnixAddr)},
+		{Name: "listen_addr", Func: stringRule(validations.ValidateListenAddr)},
+		{Name: "service_name", Func: stringRule(validations.ValidateServiceName)},
+		{Name: "port", Func: portRule(validations.PortOptions{}), Kinds: portKinds},
+		{Name: "unprivileged_port", Func: portRule(validations.PortOptions{Unprivileged: true}), Kinds: portKinds},
+		{Name: "ephemeral_port", Func: portRule(validations.PortOptions{Ephemeral: true}), Kinds: portKinds},
+		{Name: "uri", Func: stringRule(validations.ValidateURI)},
+		{Name: "url", Func: stringRule(validations.ValidateURL)},
+		{Name: "url_host", Func: stringRule(validations.ValidateURLWithHost)},
+		{Name: "uri_reference", Func: stringRule(validations.ValidateURIReference)},
+		{Name: "http_url", Func: stringRule(validations.ValidateHTTPURL)},
+		{Name: "safe_url", Func: stringRule(validations.ValidateSafeURL)},
+		{Name: "url_encoded", Func: stringRule(validations.ValidateURLEncoded)},
+		{Name: "urn_rfc2141", Func: stringRule(validations.ValidateURNRFC2141)},
+	} {
+		if r.Kinds == nil && r.Name != "required" && r.Name != "isdefault" && r.Name != "omitempty" {
+			r.Kinds = stringKinds
+		}
+		builtinRules[r.Name] = r
+	}
+}
+
+// required fails when value is missing or holds the zero value of its type.
+func required(value reflect.Value, _ string) error {
+	if !value.IsValid() || value.IsZero() {
+		return ErrRequired
+	}
+	return nil
+}
+
+// isDefault fails when value holds anything but the zero value of its type.
+func isDefault(value reflect.Value, _ string) error {
+	if value.IsValid() && !value.IsZero() {
+		return ErrNotDefault
+	}
+	return nil
+}
+
+// stringRule adapts a string validation function to a RuleFunc.
+func stringRule(fn func(string) error) RuleFunc {
+	return func(value reflect.Value, _ string) error {
+		return fn(value.String())
+	}
+}
+
+// stringParamRule adapts a string validation function taking a single
+// argument to a RuleFunc.
+func stringParamRule(fn func(string, string) error) RuleFunc {
+	return func(value reflect.Value, param string) error {
+		return fn(value.String(), param)
+	}
+}
+
+// stringListRule adapts a string validation function taking a list of
+// arguments to a RuleFunc. The parameter is split on spaces.
+func stringListRule(fn func(string, ...string) error) RuleFunc {
+	return func(value reflect.Value, param string) error {
+		return fn(value.String(), strings.Fields(param)...)
+	}
+}
+
+// stringSliceRule adapts a validation function taking a list of strings
+// to a RuleFunc applied to slices and arrays of strings.
+func stringSliceRule(fn func([]string) error) RuleFunc {
+	return func(value reflect.Value, _ string) error {
+		if value.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("%w: %s", ErrNotApplicable, value.Type())
+		}
+		list := make([]string, value.Len())
+		for i := range list {
+			list[i] = value.Index(i).String()
+		}
+		return fn(list)
+	}
+}
+
+// prefixRule returns the RuleFunc bounding the prefix length of a CIDR,
+// from below when min is set and from above otherwise.
+func prefixRule(min bool) RuleFunc {
+	return func(value reflect.Value, param string) error {
+		n, err := strconv.Atoi(param)
+		if err != nil {
+			return fmt.Errorf("%w %q", ErrInvalidParam, param)
+		}
+		if min {
+			return validations.ValidatePrefixLength(value.String(), n, 128)
+		}
+		return validations.ValidatePrefixLength(value.String(), 0, n)
+	}
+}
+
+// portRule returns the RuleFunc checking ports, given as numbers or
+// strings, against opts.
+func portRule(opts validations.PortOptions) RuleFunc {
+	return func(value reflect.Value, _ string) error {
+		if value.Kind() == reflect.String {
+			return validations.ValidatePort(value.String(), opts)
+		}
+		n, _ := toFloat(value)
+		if n > 65535 {
+			return fmt.Errorf("%w: %v", validations.ErrInvalidPort, value)
+		}
+		return validations.ValidatePortNumber(int(n), opts)
+	}
+}
+
+// intRule adapts an int predicate to a RuleFunc.
+func intRule(fn func(int) bool, err error) RuleFunc {
+	return func(value reflect.Value, _ string) error {
+		n, ok := toFloat(value)
+		if !ok || !fn(int(n)) {
+			return err
+		}
+		return nil
+	}
+}
+
+// compareRule returns a RuleFunc that compares the size of a value against
+// the parameter. The size of a number is the number itself and the size of
+// strings, slices, arrays and maps is their length. fixed, when given,
+// replaces the parameter.
+func compareRule(err error, ok func(cmp int) bool, fixed ...string) RuleFunc {
+	return func(value reflect.Value, param string) error {
+		if len(fixed) > 0 {
+			param = fixed[0]
+		}
+		want, perr := strconv.ParseFloat(param, 64)
+		if perr != nil {
+			return fmt.Errorf("%w %q", ErrInvalidParam, param)
+		}
+		got, valid := size(value)
+		if !valid {
+			return fmt.Errorf("%w: %s", ErrNotApplicable, value.Kind())
+		}
+		cmp := 0
+		switch {
+		case got < want:
+			cmp = -1
+		case got > want:
+			cmp = 1
+		}
+		if !ok(cmp) {
+			if len(fixed) > 0 {
+				return err
+			}
+			return fmt.Errorf("%w %s", err, param)
+		}
+		return nil
+	}
+}
+
+// size returns the number compared by size based rules.
+func size(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.String:
+		return float64(utf8.RuneCountInString(value.String())), true
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return float64(value.Len()), true
+	}
+	return toFloat(value)
+}
+
+// toFloat converts a numeric value to float64.
+func toFloat(value reflect.Value) (float64, bool) {
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(value.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return float64(value.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return value.Float(), true
+	}
+	return 0, false
+}
+
+// oneOf checks that value is one of the space separated values in param.
+func oneOf(value reflect.Value, param string) error {
+	for _, allowed := range strings.Fields(param) {
+		if value.Kind() == reflect.String {
+			if value.String() == allowed {
+				return nil
+			}
+			continue
+		}
+		want, err := strconv.ParseFloat(allowed, 64)
+		if err != nil {
+			return fmt.Errorf("%w %q", ErrInvalidParam, allowed)
+		}
+		if got, _ := toFloat(value); got == want {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %s", ErrOneOf, param)
+}
+
+// unique checks that a slice or array contains no duplicated elements.
+func unique(value reflect.Value, _ string) error {
+	if !value.Type().Elem().Comparable() {
+		return fmt.Errorf("%w: unique on %s", ErrNotApplicable, value.Type())
+	}
+	elems := make([]interface{}, value.Len())
+	for i := range elems {
+		elems[i] = value.Index(i).Interface()
+	}
+	if !validations.SliceIsUnique(elems, func(e interface{}) interface{} { return e }) {
+		return ErrNotUnique
+	}
+	return nil
+}
+
+// multipleOf checks that an integer is a multiple of param.
+func multipleOf(value reflect.Value, param string) error {
+	m, err := strconv.Atoi(param)
+	if err != nil || m == 0 {
+		return fmt.Errorf("%w %q", ErrInvalidParam, param)
+	}
+	n, _ := toFloat(value)
+	if !validations.IsMultipleOf(int(n), m) {
+		return fmt.Errorf("%w %s", ErrNotMultipleOf, param)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+// Command pslgen updates the Public Suffix List embedded in the validations
+// package from a local copy of the list:
+//
+//	curl -o public_suffix_list.dat https://publicsuffix.org/list/public_suffix_list.dat
+//	go run ./cmd/pslgen public_suffix_list.dat
+//
+// The license notice opening the list is kept and the other comments are
+// dropped. Unicode rules are converted to their punycode form
+// and the ICANN and private sections are kept apart. Rules that are not
+// valid domain names are reported with their line number. With -check,
+// nothing is written and the command fails when the embedded list is out
+// of date.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/solrac97gr/validator/validations"
+)
+
+func main() {
+	var (
+		output = flag.String("output", filepath.Join("validations", "psl.dat"), "file to write the embedded list to")
+		check  = flag.Bool("check", false, "fail if the embedded list is out of date instead of writing it")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: pslgen [flags] public_suffix_list.dat\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(flag.Arg(0), *output, *check); err != nil {
+		fmt.Fprintln(os.Stderr, "pslgen:", err)
+		os.Exit(1)
+	}
+}
+
+// run converts the list at input and writes it to output.
+func run(input, output string, check bool) error {
+	f, err := os.Open(input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	data, err := generate(f, input)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return os.WriteFile(output, data, 0o644)
+	}
+	current, err := os.ReadFile(output)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if !bytes.Equal(current, data) {
+		return fmt.Errorf("%s is out of date, run pslgen", output)
+	}
+	return nil
+}
+
+// generate reads a Public Suffix List from r and returns it in the format
+// embedded in the validations package: one rule per line, in ASCII, with
+// the private rules after a marker line. The first block of comments of
+// the list, its license notice, is copied after the header.
+func generate(r io.Reader, name string) ([]byte, error) {
+	var (
+		buf     bytes.Buffer
+		private bool
+		notice  = true
+		rules   int
+		seen    = make(map[string]bool)
+	)
+	fmt.Fprintf(&buf, "// Code generated by pslgen from %s. DO NOT EDIT.\n", filepath.Base(name))
+	sc := bufio.NewScanner(r)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if notice = notice && strings.HasPrefix(text, "//"); notice {
+			buf.WriteString(text + "\n")
+			continue
+		}
+		switch {
+		case strings.Contains(text, "===BEGIN PRIVATE DOMAINS==="):
+			if private {
+				return nil, fmt.Errorf("%s:%d: private section started twice", name, line)
+			}
+			private = true
+			buf.WriteString("// ===BEGIN PRIVATE DOMAINS===\n")
+			continue
+		case text == "" || strings.HasPrefix(text, "//"):
+			continue
+		}
+		// Rules end at the first white space.
+		if i := strings.IndexAny(text, " \t"); i >= 0 {
+			text = text[:i]
+		}
+		rule, err := asciiRule(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %v", name, line, err)
+		}
+		if seen[rule] {
+			continue
+		}
+		seen[rule] = true
+		buf.WriteString(rule + "\n")
+		rules++
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if rules == 0 {
+		return nil, fmt.Errorf("%s: no rules found", name)
+	}
+	return buf.Bytes(), nil
+}
+
+// asciiRule returns the ASCII form of a rule, keeping its "!" or "*."
+// prefix.
+func asciiRule(rule string) (string, error) {
+	prefix := ""
+	switch {
+	case strings.HasPrefix(rule, "!"):
+		prefix, rule = "!", rule[1:]
+	case strings.HasPrefix(rule, "*."):
+		prefix, rule = "*.", rule[2:]
+	}
+	ascii, err := validations.HostnameToASCII(rule)
+	if err != nil {
+		return "", fmt.Errorf("rule %q: %v", prefix+rule, err)
+	}
+	return prefix + ascii, nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const list = `// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+
+// Please pull this list from, and only from https://publicsuffix.org/list/public_suffix_list.dat
+
+// ===BEGIN ICANN DOMAINS===
+
+// ck : https://en.wikipedia.org/wiki/.ck
+*.ck
+!www.ck
+
+co.uk
+рф
+com   trailing text is ignored
+com
+// ===END ICANN DOMAINS===
+// ===BEGIN PRIVATE DOMAINS===
+github.io
+`
+
+func TestGenerate(t *testing.T) {
+	got, err := generate(strings.NewReader(list), "/tmp/public_suffix_list.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The license notice is kept, the other comments are dropped.
+	want := `// Code generated by pslgen from public_suffix_list.dat. DO NOT EDIT.
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at https://mozilla.org/MPL/2.0/.
+*.ck
+!www.ck
+co.uk
+xn--p1ai
+com
+// ===BEGIN PRIVATE DOMAINS===
+github.io
+`
+	if string(got) != want {
+		t.Errorf("generate() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name, list, want string
+	}{
+		{"no rules", "// nothing\n\n// at all\n", "no rules found"},
+		{"bad rule", "com\n\nexa mple.com\n-bad-.com\n", "list.dat:4:"},
+		{"private twice", "com\n// ===BEGIN PRIVATE DOMAINS===\n// ===BEGIN PRIVATE DOMAINS===\n", "list.dat:3: private section started twice"},
+	}
+	for _, tt := range tests {
+		if _, err := generate(strings.NewReader(tt.list), "list.dat"); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: generate() = %v, want an error containing %q", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	dir := t.TempDir()
+	input, output := filepath.Join(dir, "public_suffix_list.dat"), filepath.Join(dir, "psl.dat")
+	if err := os.WriteFile(input, []byte(list), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(input, output, true); err == nil {
+		t.Error("run() with check and no output = nil, want out of date")
+	}
+	if err := run(input, output, false); err != nil {
+		t.Fatalf("run() = %v", err)
+	}
+	if err := run(input, output, true); err != nil {
+		t.Errorf("run() with check = %v, want nil", err)
+	}
+	if err := os.WriteFile(input, []byte(list+"example\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := run(input, output, true); err == nil {
+		t.Error("run() with check after a change = nil, want out of date")
+	}
+}
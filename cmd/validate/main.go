@@ -0,0 +1,261 @@
+// Command validate checks values and data files from the command line,
+// for use in shell scripts and pre-commit hooks.
+//
+// Values are checked against rules written like validate tags:
+//
+//	validate ipv4 10.0.0.1
+//	validate cron "*/5 * * * *"
+//	validate required,min=3,max=20 "$USERNAME"
+//
+// JSON, YAML and CSV files are checked against the rules a rule file
+// defines for a type. The field names of the rule file are the keys of
+// the records, or dotted paths into them:
+//
+//	validate -rules rules.yaml -type User users.csv users.json
+//
+// JSON and YAML files hold a record or a list of records. CSV files have a
+// header naming the keys and their cells are checked as strings, so min=3
+// checks the length of a cell. With -numbers, cells written as JSON
+// numbers, such as 42 or 1.5e3 but not 007, are checked as numbers.
+//
+// The exit status is 0 when everything is valid, 1 when something is not
+// and 2 on usage or read errors, or when a rule cannot be checked, such as
+// a rule applied to a value of the wrong type. With -json, failures are printed as a
+// JSON array instead of one line each.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/solrac97gr/validator"
+	"github.com/solrac97gr/validator/rulefile"
+)
+
+// failure is a value or a field of a record that failed validation.
+type failure struct {
+	Source string `json:"source"`
+	Record int    `json:"record,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+	Field  string `json:"field,omitempty"`
+	Rule   string `json:"rule,omitempty"`
+	Error  string `json:"error"`
+}
+
+// String formats f for human readers.
+func (f failure) String() string {
+	var b strings.Builder
+	b.WriteString(f.Source)
+	if f.Line > 0 {
+		fmt.Fprintf(&b, ":%d", f.Line)
+		if f.Column > 0 {
+			fmt.Fprintf(&b, ":%d", f.Column)
+		}
+	}
+	b.WriteString(": ")
+	if f.Record > 0 {
+		fmt.Fprintf(&b, "record %d: ", f.Record)
+	}
+	if f.Field != "" {
+		b.WriteString(f.Field + ": ")
+	}
+	b.WriteString(f.Error)
+	return b.String()
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run runs the command with args and returns its exit status.
+func run(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var (
+		rules    = flags.String("rules", "", "rule file to check data files against")
+		typeName = flags.String("type", "", "type of the rule file to use; defaults to its only type")
+		numbers  = flags.Bool("numbers", false, "check CSV cells written as JSON numbers as numbers rather than strings")
+		asJSON   = flags.Bool("json", false, "print failures as JSON")
+		list     = flags.Bool("list", false, "list the built-in rules and exit")
+	)
+	flags.Usage = func() {
+		out := flags.Output()
+		fmt.Fprintf(out, "usage: validate [flags] rules value...\n")
+		fmt.Fprintf(out, "       validate [flags] -rules file [-type name] file...\n")
+		fmt.Fprintf(out, "\nCSV cells are strings unless -numbers is set, so min=3 checks their length.\n")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	if *list {
+		for _, r := range validator.BuiltinRules() {
+			fmt.Fprintln(stdout, r.Name)
+		}
+		return 0
+	}
+
+	val := validator.NewValidator()
+	var (
+		failures []failure
+		err      error
+	)
+	switch {
+	case *rules != "" && flags.NArg() > 0:
+		failures, err = checkFiles(val, *rules, *typeName, *numbers, flags.Args())
+	case *rules == "" && flags.NArg() >= 2:
+		failures, err = checkValues(val, flags.Arg(0), flags.Args()[1:])
+	default:
+		flags.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "validate:", err)
+		return 2
+	}
+	if err := report(stdout, failures, *asJSON); err != nil {
+		fmt.Fprintln(stderr, "validate:", err)
+		return 2
+	}
+	if len(failures) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// checkValues checks values against the rules of tag.
+func checkValues(val *validator.ValidatorImpl, tag string, values []string) ([]failure, error) {
+	if err := checkTag(val, tag); err != nil {
+		return nil, err
+	}
+	var failures []failure
+	for _, value := range values {
+		err := val.Var(value, tag)
+		for _, fe := range fieldErrors(err) {
+			if misconfigured(fe) {
+				return nil, fe
+			}
+			failures = append(failures, failure{Source: value, Rule: fe.Rule, Error: fe.Err.Error()})
+		}
+	}
+	return failures, nil
+}
+
+// checkTag reports unknown rules and bad parameters in tag.
+func checkTag(val *validator.ValidatorImpl, tag string) error {
+	rules := validator.ParseTag(tag)
+	if len(rules) == 0 {
+		return errors.New("no rules given")
+	}
+	for _, tr := range rules {
+		r, ok := val.Rule(tr.Name)
+		if !ok {
+			return fmt.Errorf("%w %q, see validate -list", validator.ErrUnknownRule, tr.Name)
+		}
+		if err := r.CheckParam(tr.Param); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkFiles checks the records of files against the rules of a type of
+// the rule file. numbers is passed to checkFile.
+func checkFiles(val *validator.ValidatorImpl, rulesPath, typeName string, numbers bool, files []string) ([]failure, error) {
+	data, err := os.ReadFile(rulesPath)
+	if err != nil {
+		return nil, err
+	}
+	rs, err := rulefile.ParseUnchecked(data, val)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rulesPath, err)
+	}
+	fields, err := typeRules(rs, typeName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", rulesPath, err)
+	}
+
+	var failures []failure
+	for _, name := range files {
+		fileFailures, err := checkFile(val, name, fields, numbers)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		failures = append(failures, fileFailures...)
+	}
+	return failures, nil
+}
+
+// typeRules returns the rules of the named type of rs as tags, for Map.
+func typeRules(rs validator.RuleSet, typeName string) (map[string]string, error) {
+	if typeName == "" {
+		if len(rs) != 1 {
+			names := make([]string, 0, len(rs))
+			for name := range rs {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			return nil, fmt.Errorf("use -type to pick one of %s", strings.Join(names, ", "))
+		}
+		for name := range rs {
+			typeName = name
+		}
+	}
+	fields, ok := rs[typeName]
+	if !ok {
+		return nil, fmt.Errorf("no rules for type %q", typeName)
+	}
+	tags := make(map[string]string, len(fields))
+	for field, rules := range fields {
+		parts := make([]string, len(rules))
+		for i, r := range rules {
+			parts[i] = r.Name
+			if r.Param != "" {
+				parts[i] += "=" + r.Param
+			}
+		}
+		tags[field] = strings.Join(parts, ",")
+	}
+	return tags, nil
+}
+
+// fieldErrors returns the field errors held by err.
+func fieldErrors(err error) validator.ValidationErrors {
+	if err == nil {
+		return nil
+	}
+	var errs validator.ValidationErrors
+	if errors.As(err, &errs) {
+		return errs
+	}
+	return validator.ValidationErrors{{Err: err}}
+}
+
+// report prints failures to w.
+func report(w io.Writer, failures []failure, asJSON bool) error {
+	if asJSON {
+		if failures == nil {
+			failures = []failure{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(failures)
+	}
+	for _, f := range failures {
+		if _, err := fmt.Fprintln(w, f); err != nil {
+			return err
+		}
+	}
+	return nil
+}
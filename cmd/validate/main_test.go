@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const ruleFile = `version: 1
+types:
+  User:
+    name: required,min=3
+    age: [required, min=18]
+`
+
+// testdata writes files to a temporary directory and returns their paths
+// by name.
+func testdata(t *testing.T, files map[string]string) map[string]string {
+	t.Helper()
+	dir := t.TempDir()
+	paths := make(map[string]string, len(files))
+	for name, content := range files {
+		paths[name] = filepath.Join(dir, name)
+		if err := os.WriteFile(paths[name], []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return paths
+}
+
+func TestRun(t *testing.T) {
+	paths := testdata(t, map[string]string{
+		"rules.yaml":   ruleFile,
+		"valid.json":   `[{"name": "Ada", "age": 36}, {"name": "Alan", "age": 41}]`,
+		"invalid.yaml": "- name: Al\n  age: 12\n- name: Grace\n  age: 85\n",
+		"users.csv":    "name,age\nAda,36\nGrace,85\n",
+		"broken.json":  `[{"name": "Ada"`,
+		"users.txt":    "Ada",
+		"other.yaml":   "version: 1\ntypes:\n  User: {name: required}\n  Team: {name: required}\n",
+		"server.yaml":  "version: 1\ntypes:\n  Server: {port: port, home.city: [required, alpha]}\n",
+		"servers.json": `[{"port": 443, "home": {"city": "Paris"}}, {"port": true, "home": {"city": "Paris"}}]`,
+		"servers.csv":  "port,home.city\n443,Paris\n8080,\n",
+		"bad.csv":      "port,home.city\n443,Paris\n\"443,Paris\n",
+		"empty.csv":    "",
+	})
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"valid value", []string{"ipv4", "10.0.0.1"}, 0},
+		{"valid values", []string{"required,min=3", "Ada", "Alan"}, 0},
+		{"invalid value", []string{"ipv4", "10.0.0.1", "10.0.0.256"}, 1},
+		{"list", []string{"-list"}, 0},
+		{"help", []string{"-h"}, 0},
+		{"valid file", []string{"-rules", paths["rules.yaml"], paths["valid.json"]}, 0},
+		{"invalid file", []string{"-rules", paths["rules.yaml"], paths["valid.json"], paths["invalid.yaml"]}, 1},
+		{"type", []string{"-rules", paths["other.yaml"], "-type", "Team", paths["valid.json"]}, 0},
+		// CSV cells are strings unless -numbers is set: "36" has 2 characters.
+		{"CSV strings", []string{"-rules", paths["rules.yaml"], paths["users.csv"]}, 1},
+		{"CSV numbers", []string{"-numbers", "-rules", paths["rules.yaml"], paths["users.csv"]}, 0},
+		{"no arguments", nil, 2},
+		{"missing value", []string{"ipv4"}, 2},
+		{"unknown flag", []string{"-nope", "ipv4", "10.0.0.1"}, 2},
+		{"unknown rule", []string{"ipv5", "10.0.0.1"}, 2},
+		// Rules that cannot be checked are errors, not invalid values.
+		{"rule on the wrong type", []string{"even", "10"}, 2},
+		{"cross-value rule", []string{"eqfield", "10"}, 2},
+		{"rule on the wrong field type", []string{"-rules", paths["server.yaml"], paths["servers.json"]}, 2},
+		// Dotted headers are the keys of nested maps.
+		{"CSV nested keys", []string{"-rules", paths["server.yaml"], paths["servers.csv"]}, 1},
+		{"CSV malformed row", []string{"-rules", paths["server.yaml"], paths["bad.csv"]}, 1},
+		{"CSV empty", []string{"-rules", paths["server.yaml"], paths["empty.csv"]}, 0},
+		{"bad parameter", []string{"min=abc", "Ada"}, 2},
+		{"missing rule file", []string{"-rules", "missing.yaml", paths["valid.json"]}, 2},
+		{"missing data file", []string{"-rules", paths["rules.yaml"], "missing.json"}, 2},
+		{"malformed file", []string{"-rules", paths["rules.yaml"], paths["broken.json"]}, 2},
+		{"unsupported file", []string{"-rules", paths["rules.yaml"], paths["users.txt"]}, 2},
+		{"ambiguous type", []string{"-rules", paths["other.yaml"], paths["valid.json"]}, 2},
+		{"unknown type", []string{"-rules", paths["rules.yaml"], "-type", "Team", paths["valid.json"]}, 2},
+	}
+	for _, tt := range tests {
+		var stdout, stderr bytes.Buffer
+		if got := run(tt.args, &stdout, &stderr); got != tt.want {
+			t.Errorf("%s: run(%q) = %d, want %d\nstdout: %s\nstderr: %s", tt.name, tt.args, got, tt.want, &stdout, &stderr)
+		}
+		if tt.want == 2 && stderr.Len() == 0 {
+			t.Errorf("%s: run(%q) printed nothing to stderr", tt.name, tt.args)
+		}
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	paths := testdata(t, map[string]string{
+		"rules.yaml": ruleFile,
+		"users.csv":  "name,age\nAda,36\nAl,12\nGrace,85\n",
+	})
+
+	var stdout, stderr bytes.Buffer
+	if got := run([]string{"-json", "-numbers", "-rules", paths["rules.yaml"], paths["users.csv"]}, &stdout, &stderr); got != 1 {
+		t.Fatalf("run() = %d, want 1\nstderr: %s", got, &stderr)
+	}
+	var failures []failure
+	if err := json.Unmarshal(stdout.Bytes(), &failures); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, &stdout)
+	}
+	// Al is too short and, with -numbers, too young.
+	want := []failure{
+		{Source: paths["users.csv"], Record: 2, Line: 3, Column: 2, Field: "age", Rule: "min"},
+		{Source: paths["users.csv"], Record: 2, Line: 3, Column: 1, Field: "name", Rule: "min"},
+	}
+	if len(failures) != len(want) {
+		t.Fatalf("got %d failures, want %d:\n%s", len(failures), len(want), &stdout)
+	}
+	for i, f := range failures {
+		if f.Error == "" {
+			t.Errorf("failure %d has no error", i)
+		}
+		f.Error = ""
+		if f != want[i] {
+			t.Errorf("failure %d = %+v, want %+v", i, f, want[i])
+		}
+	}
+
+	stdout.Reset()
+	if got := run([]string{"-json", "ipv4", "10.0.0.1"}, &stdout, &stderr); got != 0 {
+		t.Fatalf("run() = %d, want 0", got)
+	}
+	if out := strings.TrimSpace(stdout.String()); out != "[]" {
+		t.Errorf("output = %s, want []", out)
+	}
+}
+
+func TestCSVNumber(t *testing.T) {
+	tests := []struct {
+		cell string
+		want float64
+		ok   bool
+	}{
+		{"42", 42, true},
+		{"-1.5e3", -1500, true},
+		{"0", 0, true},
+		{"0.25", 0.25, true},
+		{"007", 0, false},
+		{"+1", 0, false},
+		{"1.", 0, false},
+		{"1e", 0, false},
+		{"0x10", 0, false},
+		{"Inf", 0, false},
+		{"1_000", 0, false},
+		{" 1", 0, false},
+	}
+	for _, tt := range tests {
+		if got, ok := csvNumber(tt.cell); got != tt.want || ok != tt.ok {
+			t.Errorf("csvNumber(%q) = %v, %v, want %v, %v", tt.cell, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/solrac97gr/validator"
+	"github.com/solrac97gr/validator/stream"
+	"gopkg.in/yaml.v3"
+)
+
+// checkFile checks the records of the named file against rules, keyed by
+// path as for Map. When numbers is set, CSV cells written as JSON numbers
+// are checked as numbers.
+func checkFile(val *validator.ValidatorImpl, name string, rules map[string]string, numbers bool) ([]failure, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var doc interface{}
+	switch ext := strings.ToLower(filepath.Ext(name)); ext {
+	case ".csv":
+		return checkCSV(val, f, name, rules, numbers)
+	case ".json":
+		if err := json.NewDecoder(f).Decode(&doc); err != nil {
+			return nil, err
+		}
+	case ".yaml", ".yml":
+		if err := yaml.NewDecoder(f).Decode(&doc); err != nil && err != io.EOF {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported file type %q", ext)
+	}
+	records, err := docRecords(doc)
+	if err != nil {
+		return nil, err
+	}
+	var failures []failure
+	for i, rec := range records {
+		for _, fe := range fieldErrors(val.Map(rec, rules)) {
+			f, err := newFailure(name, i+1, 0, 0, fe)
+			if err != nil {
+				return nil, err
+			}
+			failures = append(failures, f)
+		}
+	}
+	return failures, nil
+}
+
+// docRecords returns the records of a decoded JSON or YAML document: the
+// document itself or the elements of a list.
+func docRecords(doc interface{}) ([]map[string]interface{}, error) {
+	switch doc := doc.(type) {
+	case map[string]interface{}:
+		return []map[string]interface{}{doc}, nil
+	case []interface{}:
+		records := make([]map[string]interface{}, len(doc))
+		for i, elem := range doc {
+			rec, ok := elem.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("record %d is not an object", i+1)
+			}
+			records[i] = rec
+		}
+		return records, nil
+	case nil:
+		return nil, nil
+	}
+	return nil, errors.New("expected an object or a list of objects")
+}
+
+// checkCSV checks the rows of a CSV file, keyed by the names of its header,
+// with a stream.CSVMap.
+func checkCSV(val *validator.ValidatorImpl, r io.Reader, name string, rules map[string]string, numbers bool) ([]failure, error) {
+	cr := stream.NewCSVMap(r, val, rules)
+	if numbers {
+		cr.Cell = func(cell string) interface{} {
+			if n, ok := csvNumber(cell); ok {
+				return n
+			}
+			return cell
+		}
+	}
+	var failures []failure
+	for index := 1; cr.Next(); index++ {
+		rep := cr.Report()
+		for _, issue := range rep.Issues {
+			var fe *validator.FieldError
+			if !errors.As(issue.Err, &fe) {
+				fe = &validator.FieldError{Field: issue.Field, Err: issue.Err}
+			}
+			f, err := newFailure(name, index, rep.Line, issue.Column, fe)
+			if err != nil {
+				return nil, err
+			}
+			failures = append(failures, f)
+		}
+	}
+	if err := cr.Err(); err != nil && !errors.Is(err, stream.ErrNoHeader) {
+		return nil, err
+	}
+	return failures, nil
+}
+
+// newFailure returns the failure of a field of a record. It returns an
+// error instead when fe comes from rules that cannot be checked, such as
+// a rule applied to a value of the wrong type, rather than from an
+// invalid value.
+func newFailure(source string, record, line, column int, fe *validator.FieldError) (failure, error) {
+	if misconfigured(fe) {
+		return failure{}, fmt.Errorf("record %d: %w", record, fe)
+	}
+	return failure{
+		Source: source,
+		Record: record,
+		Line:   line,
+		Column: column,
+		Field:  fe.Field,
+		Rule:   fe.Rule,
+		Error:  fe.Err.Error(),
+	}, nil
+}
+
+// misconfigured reports whether fe comes from rules that cannot be checked.
+func misconfigured(fe *validator.FieldError) bool {
+	return errors.Is(fe, validator.ErrNotApplicable) || errors.Is(fe, validator.ErrUnknownRule) || errors.Is(fe, validator.ErrInvalidParam)
+}
+
+// csvNumber returns the number held by cell if it is written as a JSON
+// number, so codes with leading zeros such as "007" stay strings.
+func csvNumber(cell string) (float64, bool) {
+	if strings.Trim(cell, "+-.0123456789eE") != "" {
+		return 0, false
+	}
+	var n float64
+	if err := json.Unmarshal([]byte(cell), &n); err != nil {
+		return 0, false
+	}
+	return n, true
+}
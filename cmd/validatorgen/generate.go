@@ -0,0 +1,879 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/solrac97gr/validator"
+	"golang.org/x/tools/go/packages"
+)
+
+// stringFuncs maps rules to the validations function checking them.
+var stringFuncs = map[string]string{
+	"alpha":              "StringIsAlpha",
+	"alphanum":           "StringIsAlphanumeric",
+	"alphaunicode":       "StringIsAlphaUnicode",
+	"alphanumunicode":    "StringIsAlphanumericUnicode",
+	"ascii":              "StringIsASCIICode",
+	"printascii":         "StringIsPrintableASCII",
+	"boolean":            "StringIsBoolean",
+	"numeric":            "StringIsNumeric",
+	"lowercase":          "StringIsLowerCase",
+	"uppercase":          "StringIsUpperCase",
+	"multibyte":          "StringIsMultibyte",
+	"base64":             "IsBase64",
+	"base64url":          "IsBase64URL",
+	"base64rawurl":       "IsBase64RawURL",
+	"bic":                "IsBIC",
+	"bcp47_language_tag": "IsBCP47LanguageTag",
+	"btc_addr":           "IsBTCAddress",
+	"credit_card":        "IsValidCreditCard",
+	"mongodb":            "IsValidMongoID",
+	"cron":               "IsValidCron",
+	"datetime":           "IsValidDatetime",
+	"e164":               "IsValidE164PhoneNumber",
+	"email":              "IsValidEmail",
+	"ip":                 "ValidateIPAddress",
+	"ipv4":               "ValidateIPv4Address",
+	"ipv6":               "ValidateIPv6Address",
+	"hostname":           "ValidateHostname",
+	"hostname_rfc952":    "ValidateRFC952",
+	"fqdn":               "ValidateFQDN",
+	"idn_hostname":       "ValidateUnicodeHostname",
+	"punycode_hostname":  "ValidatePunycodeHostname",
+	"registrable_domain": "ValidateRegistrableDomain",
+	"not_public_suffix":  "ValidateNotPublicSuffix",
+	"mac":                "ValidateMACAddress",
+	"mac48":              "ValidateMAC48",
+	"eui64":              "ValidateEUI64",
+	"unicast_mac":        "ValidateUnicastMAC",
+	"multicast_mac":      "ValidateMulticastMAC",
+	"universal_mac":      "ValidateUniversalMAC",
+	"local_mac":          "ValidateLocalMAC",
+	"cidrv4":             "ValidateCIDRv4",
+	"cidrv6":             "ValidateCIDRv6",
+	"cidr":               "ValidateCIDR",
+	"datauri":            "ValidateDataURL",
+	"tcp4_addr":          "ValidateTCP4Addr",
+	"tcp6_addr":          "ValidateTCP6Addr",
+	"tcp_addr":           "ValidateTCPAddr",
+	"hostname_port":      "ValidateHostnamePort",
+	"ip_port":            "ValidateIPPort",
+	"private_ip":         "ValidatePrivateIP",
+	"loopback_ip":        "ValidateLoopbackIP",
+	"link_local_ip":      "ValidateLinkLocalIP",
+	"multicast_ip":       "ValidateMulticastIP",
+	"unspecified_ip":     "ValidateUnspecifiedIP",
+	"documentation_ip":   "ValidateDocumentationIP",
+	"cgnat_ip":           "ValidateCGNATIP",
+	"public_ip":          "ValidatePublicIP",
+	"udp4_addr":          "ValidateUDP4Addr",
+	"udp6_addr":          "ValidateUDP6Addr",
+	"udp_addr":           "ValidateUDPAddr",
+	"unix_addr":          "ValidateUnixAddr",
+	"listen_addr":        "ValidateListenAddr",
+	"service_name":       "ValidateServiceName",
+	"port":               "ValidatePortString",
+	"unprivileged_port":  "ValidateUnprivilegedPort",
+	"ephemeral_port":     "ValidateEphemeralPort",
+	"uri":                "ValidateURI",
+	"url":                "ValidateURL",
+	"url_host":           "ValidateURLWithHost",
+	"uri_reference":      "ValidateURIReference",
+	"http_url":           "ValidateHTTPURL",
+	"safe_url":           "ValidateSafeURL",
+	"url_encoded":        "ValidateURLEncoded",
+	"urn_rfc2141":        "ValidateURNRFC2141",
+}
+
+// stringParamFuncs maps rules taking a parameter to the validations
+// function checking them.
+var stringParamFuncs = map[string]string{
+	"contains":      "StringContains",
+	"excludes":      "StringExcludes",
+	"startswith":    "StringStartsWith",
+	"startsnotwith": "StringStartsNotWith",
+	"endswith":      "StringEndsWith",
+	"endsnotwith":   "StringEndsNotWith",
+	"containsany":   "StringContainsAny",
+	"excludesall":   "StringExcludesAll",
+	"in_cidr":       "ValidateInCIDRs",
+}
+
+// comparisons maps size based rules to the Go operator that must hold and
+// the error reported when it does not.
+var comparisons = map[string][2]string{
+	"min": {">=", "ErrMin"},
+	"gte": {">=", "ErrMin"},
+	"max": {"<=", "ErrMax"},
+	"lte": {"<=", "ErrMax"},
+	"len": {"==", "ErrLen"},
+	"eq":  {"==", "ErrEq"},
+	"ne":  {"!=", "ErrNe"},
+	"gt":  {">", "ErrGt"},
+	"lt":  {"<", "ErrLt"},
+}
+
+const (
+	validatorPkg   = "github.com/solrac97gr/validator"
+	validationsPkg = "github.com/solrac97gr/validator/validations"
+)
+
+// generate returns the source of the file holding the Validate methods of
+// the package in dir. The current content of output is ignored.
+func generate(dir, output string, names []string) ([]byte, error) {
+	abs, err := filepath.Abs(output)
+	if err != nil {
+		return nil, err
+	}
+	cfg := &packages.Config{
+		Mode:    packages.NeedName | packages.NeedTypes | packages.NeedSyntax | packages.NeedTypesInfo,
+		Dir:     dir,
+		Overlay: map[string][]byte{},
+	}
+	pkgs, err := packages.Load(cfg, ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("expected one package in %s, found %d", dir, len(pkgs))
+	}
+	pkg := pkgs[0]
+	// Hide the previous output so its methods don't count as hand-written.
+	cfg.Overlay[abs] = []byte("package " + pkg.Name + "\n")
+	if pkgs, err = packages.Load(cfg, "."); err != nil {
+		return nil, err
+	}
+	pkg = pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, pkg.Errors[0]
+	}
+
+	g := &generator{pkg: pkg.Types, imports: map[string]bool{}, targets: map[*types.Named]bool{}}
+	if err := g.selectTargets(names); err != nil {
+		return nil, err
+	}
+	return g.file()
+}
+
+// generator writes the Validate methods of a package.
+type generator struct {
+	pkg     *types.Package
+	buf     bytes.Buffer
+	imports map[string]bool
+	targets map[*types.Named]bool
+	order   []*types.Named
+	// st is the struct whose method is being written.
+	st *types.Struct
+	// grouped and counted are set when the method being written reads
+	// the validated groups and counts the errors of a field.
+	grouped bool
+	counted bool
+}
+
+// selectTargets picks the struct types to generate methods for.
+func (g *generator) selectTargets(names []string) error {
+	scope := g.pkg.Scope()
+	if names == nil {
+		for _, name := range scope.Names() {
+			named, ok := structType(scope.Lookup(name))
+			if ok && hasTags(named) && !hasValidate(named) {
+				g.add(named)
+			}
+		}
+		return nil
+	}
+	for _, name := range names {
+		named, ok := structType(scope.Lookup(strings.TrimSpace(name)))
+		if !ok {
+			return fmt.Errorf("%s is not a struct type of package %s", name, g.pkg.Name())
+		}
+		if hasValidate(named) {
+			return fmt.Errorf("%s already has a Validate method", name)
+		}
+		g.add(named)
+	}
+	return nil
+}
+
+// add marks named as a type to generate a method for.
+func (g *generator) add(named *types.Named) {
+	g.targets[named] = true
+	g.order = append(g.order, named)
+}
+
+// file returns the formatted source of the generated file.
+func (g *generator) file() ([]byte, error) {
+	for _, named := range g.order {
+		if err := g.method(named); err != nil {
+			return nil, err
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by validatorgen. DO NOT EDIT.\n\npackage %s\n\n", g.pkg.Name())
+	if len(g.imports) > 0 {
+		paths := make([]string, 0, len(g.imports))
+		for path := range g.imports {
+			paths = append(paths, path)
+		}
+		sort.Strings(paths)
+		out.WriteString("import (\n")
+		for _, std := range []bool{true, false} {
+			for _, path := range paths {
+				if isStd(path) == std {
+					fmt.Fprintf(&out, "\t%q\n", path)
+				}
+			}
+			out.WriteString("\n")
+		}
+		out.WriteString(")\n\n")
+	}
+	out.Write(g.buf.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting generated code: %w", err)
+	}
+	return src, nil
+}
+
+// method writes the Validate method of named.
+func (g *generator) method(named *types.Named) error {
+	name := named.Obj().Name()
+	st := named.Underlying().(*types.Struct)
+	g.imports[validatorPkg] = true
+
+	tags := make([]map[string][]validator.TagRule, st.NumFields())
+	for i := 0; i < st.NumFields(); i++ {
+		tags[i] = validator.ParseTagGroups(reflect.StructTag(st.Tag(i)).Get(validator.TagName))
+	}
+
+	fmt.Fprintf(&g.buf, "// Validate validates the fields of %s according to their validate tags.\n", name)
+	fmt.Fprintf(&g.buf, "func (s %s) Validate(args ...interface{}) error {\n", name)
+	g.buf.WriteString("\tvar errs validator.ValidationErrors\n")
+	start := g.buf.Len()
+	g.st, g.grouped, g.counted = st, false, false
+	for i := 0; i < st.NumFields(); i++ {
+		f := st.Field(i)
+		// Unexported embedded structs are validated like exported fields,
+		// as the validator promotes their fields.
+		if !f.Exported() && !(f.Embedded() && isStruct(f.Type())) || reflect.StructTag(st.Tag(i)).Get(validator.TagName) == "-" {
+			continue
+		}
+		if err := g.field(f, tags[i]); err != nil {
+			return fmt.Errorf("%s.%s: %w", name, f.Name(), err)
+		}
+	}
+	// Declare the variables used by the checks of the fields.
+	fields := append([]byte(nil), g.buf.Bytes()[start:]...)
+	g.buf.Truncate(start)
+	if g.grouped {
+		g.buf.WriteString("\tgroups := validator.GroupsFromArgs(args...)\n")
+	}
+	if g.counted {
+		g.buf.WriteString("\tvar n int\n")
+	}
+	g.buf.Write(fields)
+	g.buf.WriteString("\tif len(errs) > 0 {\n\t\treturn errs\n\t}\n\treturn nil\n}\n\n")
+	fmt.Fprintf(&g.buf, "// ValidatorGenerated marks %s as validated by generated code.\n", name)
+	fmt.Fprintf(&g.buf, "func (%s) ValidatorGenerated() interface{} { return (*%s)(nil) }\n\n", name, name)
+	return nil
+}
+
+// check is a single condition of the else-if chain validating a field.
+type check struct {
+	init string
+	cond string
+	rule string
+	prm  string
+	err  string
+}
+
+// field writes the checks of a struct field. The rules of each group,
+// including DefaultGroup, only run when the group is validated and no
+// previous group failed, like StructGroups does.
+func (g *generator) field(f *types.Var, tags map[string][]validator.TagRule) error {
+	names := make([]string, 0, len(tags))
+	for group := range tags {
+		if group != validator.DefaultGroup {
+			names = append(names, group)
+		}
+	}
+	sort.Strings(names)
+	if _, ok := tags[validator.DefaultGroup]; ok {
+		names = append([]string{validator.DefaultGroup}, names...)
+	}
+
+	var blocks bytes.Buffer
+	count := 0
+	for _, group := range names {
+		checks, guards, err := g.checks(f, tags[group])
+		if err != nil {
+			return err
+		}
+		if len(checks) == 0 {
+			continue
+		}
+		count++
+		g.grouped = true
+		cond := fmt.Sprintf("groups.Has(%q)", group)
+		if count > 1 {
+			cond = "len(errs) == n && " + cond
+		}
+		fmt.Fprintf(&blocks, "\tif %s {\n", cond)
+		writeChain(&blocks, f.Name(), checks, guards, "", "\t\t")
+		blocks.WriteString("\t}\n")
+	}
+	nested, err := g.nested(strconv.Quote(f.Name()), "s."+f.Name(), f.Type(), 0, nil)
+	if err != nil {
+		return err
+	}
+	if blocks.Len() == 0 && nested == "" {
+		return nil
+	}
+	fmt.Fprintf(&g.buf, "\t// %s\n", f.Name())
+	if count == 1 && nested == "" || count == 0 {
+		g.buf.Write(blocks.Bytes())
+		g.buf.WriteString(indentLines(nested, "\t"))
+		return nil
+	}
+	g.counted = true
+	g.buf.WriteString("\tn = len(errs)\n")
+	g.buf.Write(blocks.Bytes())
+	if nested != "" {
+		g.buf.WriteString("\tif len(errs) == n {\n")
+		g.buf.WriteString(indentLines(nested, "\t\t"))
+		g.buf.WriteString("\t}\n")
+	}
+	return nil
+}
+
+// checks returns the checks of the rules of a field and the conditions
+// guarding them.
+func (g *generator) checks(f *types.Var, rules []validator.TagRule) ([]check, []string, error) {
+	expr := "s." + f.Name()
+	t := f.Type()
+	pointer := false
+	if p, ok := t.(*types.Pointer); ok {
+		pointer, t = true, p.Elem()
+	}
+	value := expr
+	if pointer {
+		value = "*" + expr
+	}
+
+	var checks []check
+	omitempty, required := false, false
+	for _, r := range rules {
+		switch r.Name {
+		case "omitempty":
+			omitempty = true
+			continue
+		case "required":
+			required = true
+		case "isdefault":
+			zero, err := g.zero(value, t)
+			if err != nil {
+				return nil, nil, err
+			}
+			checks = append(checks, check{cond: "!(" + zero + ")", rule: r.Name, err: "validator.ErrNotDefault"})
+			continue
+		}
+		c, err := g.rule(r, value, t)
+		if err != nil {
+			return nil, nil, err
+		}
+		if r.Name == "required" && pointer {
+			c.cond = expr + " == nil || " + c.cond
+		}
+		checks = append(checks, c)
+	}
+
+	var guards []string
+	if pointer && !required {
+		guards = append(guards, expr+" != nil")
+	}
+	if omitempty {
+		zero, err := g.zero(value, t)
+		if err != nil {
+			return nil, nil, err
+		}
+		guards = append(guards, "!("+zero+")")
+	}
+	return checks, guards, nil
+}
+
+// writeChain writes the else-if chain of checks of a field to buf, followed
+// by the validation of its nested structs when every check passes.
+func writeChain(buf *bytes.Buffer, field string, checks []check, guards []string, nested, indent string) {
+	outer := indent
+	if len(guards) > 0 {
+		fmt.Fprintf(buf, "%sif %s {\n", outer, strings.Join(guards, " && "))
+		indent += "\t"
+	}
+	for i, c := range checks {
+		keyword := "if "
+		if i > 0 {
+			keyword = "} else if "
+		}
+		buf.WriteString(indent + keyword)
+		if c.init != "" {
+			buf.WriteString(c.init + "; ")
+		}
+		fmt.Fprintf(buf, "%s {\n", c.cond)
+		param := ""
+		if c.prm != "" {
+			param = fmt.Sprintf(", Param: %q", c.prm)
+		}
+		fmt.Fprintf(buf, "%s\terrs = append(errs, &validator.FieldError{Field: %q, Rule: %q%s, Err: %s})\n",
+			indent, field, c.rule, param, c.err)
+	}
+	if nested != "" {
+		if len(checks) > 0 {
+			buf.WriteString(indent + "} else {\n")
+			buf.WriteString(indentLines(nested, indent+"\t"))
+			buf.WriteString(indent + "}\n")
+		} else {
+			buf.WriteString(indentLines(nested, indent))
+		}
+	} else {
+		buf.WriteString(indent + "}\n")
+	}
+	if len(guards) > 0 {
+		buf.WriteString(outer + "}\n")
+	}
+}
+
+// rule returns the check of a single rule against value, of type t.
+func (g *generator) rule(r validator.TagRule, value string, t types.Type) (check, error) {
+	c := check{rule: r.Name, prm: r.Param}
+	basic, _ := t.Underlying().(*types.Basic)
+	isString := basic != nil && basic.Info()&types.IsString != 0
+	isNumber := basic != nil && basic.Info()&types.IsNumeric != 0
+	isInt := basic != nil && basic.Info()&types.IsInteger != 0
+
+	str := value
+	if isString && t != types.Typ[types.String] {
+		str = "string(" + value + ")"
+	}
+	call := func(fn string, args ...string) check {
+		g.imports[validationsPkg] = true
+		c.init = "err := validations." + fn + "(" + strings.Join(append([]string{str}, args...), ", ") + ")"
+		c.cond, c.err = "err != nil", "err"
+		return c
+	}
+	fail := func(cond, sentinel string, withParam bool) check {
+		c.cond = cond
+		c.err = "validator." + sentinel
+		if withParam {
+			g.imports["fmt"] = true
+			c.err = fmt.Sprintf("fmt.Errorf(%q, validator.%s)", "%w "+strings.ReplaceAll(r.Param, "%", "%%"), sentinel)
+		}
+		return c
+	}
+
+	if fn, ok := stringFuncs[r.Name]; ok && isString {
+		return call(fn), nil
+	}
+	if fn, ok := stringParamFuncs[r.Name]; ok && isString {
+		if r.Name == "containsany" || r.Name == "excludesall" || r.Name == "in_cidr" {
+			args := []string{}
+			for _, p := range strings.Fields(r.Param) {
+				args = append(args, strconv.Quote(p))
+			}
+			return call(fn, args...), nil
+		}
+		return call(fn, strconv.Quote(r.Param)), nil
+	}
+
+	switch r.Name {
+	case "eqfield", "nefield", "gtfield", "gtefield", "ltfield", "ltefield":
+		return g.crossField(c, r, value, t)
+	case "required":
+		zero, err := g.zero(value, t)
+		if err != nil {
+			return c, err
+		}
+		return fail(zero, "ErrRequired", false), nil
+	case "min_prefix", "max_prefix":
+		if n, err := strconv.Atoi(r.Param); err != nil || n < 0 {
+			return c, fmt.Errorf("%w %q", validator.ErrInvalidParam, r.Param)
+		}
+		if !isString {
+			break
+		}
+		if r.Name == "min_prefix" {
+			return call("ValidatePrefixLength", r.Param, "128"), nil
+		}
+		return call("ValidatePrefixLength", "0", r.Param), nil
+	case "cidr_no_overlap":
+		if s, ok := t.Underlying().(*types.Slice); ok && types.Identical(s.Elem(), types.Typ[types.String]) {
+			return call("ValidateNoOverlap"), nil
+		}
+	case "port", "unprivileged_port", "ephemeral_port":
+		if isInt {
+			g.imports[validationsPkg] = true
+			opts := map[string]string{"unprivileged_port": "Unprivileged: true", "ephemeral_port": "Ephemeral: true"}[r.Name]
+			c.init = "err := validations.ValidatePortNumber(int(" + value + "), validations.PortOptions{" + opts + "})"
+			c.cond, c.err = "err != nil", "err"
+			return c, nil
+		}
+	case "eth_addr":
+		if isString {
+			g.imports[validationsPkg] = true
+			c.cond, c.err = "!validations.IsEthAddress("+str+")", "validations.ErrInvalidEthAddress"
+			return c, nil
+		}
+	case "min", "max", "len", "eq", "ne", "gt", "gte", "lt", "lte":
+		if _, err := strconv.ParseFloat(r.Param, 64); err != nil {
+			return c, fmt.Errorf("%w %q", validator.ErrInvalidParam, r.Param)
+		}
+		op := comparisons[r.Name]
+		size, err := g.size(value, t, r.Name)
+		if err != nil {
+			return c, err
+		}
+		if err := constParam(r.Param, t); err != nil {
+			return c, err
+		}
+		return fail("!("+size+" "+op[0]+" "+r.Param+")", op[1], true), nil
+	case "positive":
+		if isNumber {
+			return fail("!("+value+" > 0)", "ErrNotPositive", false), nil
+		}
+	case "negative":
+		if isNumber {
+			return fail("!("+value+" < 0)", "ErrNotNegative", false), nil
+		}
+	case "even", "odd", "multipleof":
+		if isInt {
+			g.imports[validationsPkg] = true
+			switch r.Name {
+			case "even":
+				return fail("!validations.IsEven(int("+value+"))", "ErrNotEven", false), nil
+			case "odd":
+				return fail("!validations.IsOdd(int("+value+"))", "ErrNotOdd", false), nil
+			}
+			if n, err := strconv.Atoi(r.Param); err != nil || n == 0 {
+				return c, fmt.Errorf("%w %q", validator.ErrInvalidParam, r.Param)
+			}
+			return fail("!validations.IsMultipleOf(int("+value+"), "+r.Param+")", "ErrNotMultipleOf", true), nil
+		}
+	case "oneof":
+		if isString || isNumber {
+			var allowed []string
+			for _, p := range strings.Fields(r.Param) {
+				if isString {
+					allowed = append(allowed, strconv.Quote(p))
+					continue
+				}
+				if _, err := strconv.ParseFloat(p, 64); err != nil {
+					return c, fmt.Errorf("%w %q", validator.ErrInvalidParam, p)
+				}
+				if err := constParam(p, t); err != nil {
+					return c, err
+				}
+				allowed = append(allowed, p)
+			}
+			conds := make([]string, len(allowed))
+			for i, a := range allowed {
+				conds[i] = value + " != " + a
+			}
+			return fail(strings.Join(conds, " && "), "ErrOneOf", true), nil
+		}
+	case "unique":
+		if s, ok := t.Underlying().(*types.Slice); ok && types.Comparable(s.Elem()) {
+			g.imports[validationsPkg] = true
+			elem := g.typeString(s.Elem())
+			return fail("!validations.SliceIsUnique("+value+", func(e "+elem+") interface{} { return e })", "ErrNotUnique", false), nil
+		}
+	}
+	if _, ok := builtin(r.Name); !ok {
+		return c, fmt.Errorf("%w %q", validator.ErrUnknownRule, r.Name)
+	}
+	return c, fmt.Errorf("%w: %s on %s", validator.ErrNotApplicable, r.Name, t)
+}
+
+// crossField returns the check of a rule comparing value with a sibling
+// field of the same type, named by the rule's parameter.
+func (g *generator) crossField(c check, r validator.TagRule, value string, t types.Type) (check, error) {
+	var other *types.Var
+	for i := 0; i < g.st.NumFields(); i++ {
+		if f := g.st.Field(i); f.Name() == r.Param {
+			other = f
+		}
+	}
+	if other == nil {
+		return c, fmt.Errorf("%w: %s has no field %s", validator.ErrInvalidParam, r.Name, r.Param)
+	}
+	if !types.Identical(t, other.Type()) {
+		return c, fmt.Errorf("%w: %s between %s and %s", validator.ErrNotApplicable, r.Name, t, other.Type())
+	}
+	op := comparisons[strings.TrimSuffix(r.Name, "field")]
+	basic, _ := t.Underlying().(*types.Basic)
+	ordered := basic != nil && basic.Info()&types.IsOrdered != 0
+	switch {
+	case isTime(t):
+		c.cond = "!(" + value + ".Compare(s." + r.Param + ") " + op[0] + " 0)"
+	case ordered || (op[0] == "==" || op[0] == "!=") && types.Comparable(t):
+		c.cond = "!(" + value + " " + op[0] + " s." + r.Param + ")"
+	default:
+		return c, fmt.Errorf("%w: %s on %s", validator.ErrNotApplicable, r.Name, t)
+	}
+	g.imports["fmt"] = true
+	c.err = fmt.Sprintf("fmt.Errorf(%q, validator.%s)", "%w "+strings.ReplaceAll(r.Param, "%", "%%"), op[1])
+	return c, nil
+}
+
+// constParam returns an error unless param is a constant of the type
+// compared by the size based rules on t, so that the generated comparison
+// compiles: min=1.5 does not apply to an int, nor min=-1 to a uint.
+func constParam(param string, t types.Type) error {
+	name := "int"
+	if basic, ok := t.Underlying().(*types.Basic); ok && basic.Info()&types.IsNumeric != 0 {
+		name = basic.Name()
+	}
+	if _, err := types.Eval(token.NewFileSet(), nil, token.NoPos, name+"("+param+")"); err != nil {
+		return fmt.Errorf("%w %q for %s", validator.ErrInvalidParam, param, t)
+	}
+	return nil
+}
+
+// isTime reports whether t is time.Time.
+func isTime(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+// size returns the expression compared by size based rules.
+func (g *generator) size(value string, t types.Type, rule string) (string, error) {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			if rule == "eq" || rule == "ne" {
+				break
+			}
+			g.imports["unicode/utf8"] = true
+			if t != types.Typ[types.String] {
+				value = "string(" + value + ")"
+			}
+			return "utf8.RuneCountInString(" + value + ")", nil
+		case u.Info()&types.IsNumeric != 0:
+			return value, nil
+		}
+	case *types.Slice, *types.Map, *types.Array:
+		if rule == "eq" || rule == "ne" {
+			break
+		}
+		return "len(" + value + ")", nil
+	}
+	return "", fmt.Errorf("%w: %s on %s", validator.ErrNotApplicable, rule, t)
+}
+
+// zero returns an expression reporting whether value, of type t, is the
+// zero value of its type.
+func (g *generator) zero(value string, t types.Type) (string, error) {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Info()&types.IsString != 0:
+			return value + ` == ""`, nil
+		case u.Info()&types.IsNumeric != 0:
+			return value + " == 0", nil
+		case u.Info()&types.IsBoolean != 0:
+			return "!" + value, nil
+		}
+	case *types.Slice, *types.Map, *types.Pointer, *types.Interface, *types.Chan, *types.Signature:
+		return value + " == nil", nil
+	case *types.Struct, *types.Array:
+		if types.Comparable(t) {
+			return value + " == (" + g.typeString(t) + "{})", nil
+		}
+	}
+	return "", fmt.Errorf("%w: cannot compare %s to its zero value", validator.ErrNotApplicable, t)
+}
+
+// typeString returns the name of t in the generated file, importing the
+// packages it refers to.
+func (g *generator) typeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg == g.pkg {
+			return ""
+		}
+		g.imports[pkg.Path()] = true
+		return pkg.Name()
+	})
+}
+
+// nested returns the code validating the structs held by a field, directly
+// or through pointers, slices, arrays and maps, as the validator dives into
+// them. path is the expression of the field's name in errors and value the
+// field itself, not dereferenced. depth numbers the loop variables and seen
+// holds the named types being walked, to stop at recursive types. It
+// returns "" when there are none.
+func (g *generator) nested(path, value string, t types.Type, depth int, seen map[*types.Named]bool) (string, error) {
+	if g.validatable(t) {
+		return fmt.Sprintf("if err := %s.Validate(args...); err != nil {\n\terrs = append(errs, validator.NestErrors(%s, err)...)\n}\n", value, path), nil
+	}
+	if named, ok := t.(*types.Named); ok {
+		if seen[named] {
+			return "", nil
+		}
+		if seen == nil {
+			seen = make(map[*types.Named]bool)
+		}
+		seen[named] = true
+		defer delete(seen, named)
+	}
+	suffix := ""
+	if depth > 0 {
+		suffix = strconv.Itoa(depth)
+	}
+	index := func(expr string) string {
+		return strings.TrimSuffix(path, `"`) + `["+` + expr + `+"]"`
+	}
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		elem := value
+		if !g.validatable(u.Elem()) {
+			elem = "(*" + value + ")"
+		}
+		code, err := g.nested(path, elem, u.Elem(), depth, seen)
+		if code == "" || err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("if %s != nil {\n%s}\n", value, indentLines(code, "\t")), nil
+	case *types.Slice, *types.Array:
+		i, e := "i"+suffix, "e"+suffix
+		elem := u.(interface{ Elem() types.Type }).Elem()
+		code, err := g.nested(index("strconv.Itoa("+i+")"), e, elem, depth+1, seen)
+		if code == "" || err != nil {
+			return "", err
+		}
+		g.imports["strconv"] = true
+		return fmt.Sprintf("for %s, %s := range %s {\n%s}\n", i, e, value, indentLines(code, "\t")), nil
+	case *types.Map:
+		k, e := "k"+suffix, "e"+suffix
+		key, ok := mapKey(k, u.Key())
+		code, err := g.nested(index(key), e, u.Elem(), depth+1, seen)
+		if code == "" || err != nil {
+			return "", err
+		}
+		if !ok {
+			return "", fmt.Errorf("%w: structs in maps keyed by %s", validator.ErrNotApplicable, u.Key())
+		}
+		if key != k && key != "string("+k+")" {
+			g.imports["strconv"] = true
+		}
+		return fmt.Sprintf("for %s, %s := range %s {\n%s}\n", k, e, value, indentLines(code, "\t")), nil
+	}
+	return "", nil
+}
+
+// mapKey returns the expression of the map key k, of type t, in the paths
+// of errors, formatted like the validator does. It reports whether keys of
+// type t can be formatted.
+func mapKey(k string, t types.Type) (string, bool) {
+	basic, ok := t.Underlying().(*types.Basic)
+	switch {
+	case !ok:
+		return "", false
+	case basic.Info()&types.IsString != 0:
+		if t != types.Typ[types.String] {
+			return "string(" + k + ")", true
+		}
+		return k, true
+	case basic.Info()&(types.IsInteger|types.IsFloat) != 0:
+		return "strconv.FormatFloat(float64(" + k + "), 'f', -1, 64)", true
+	}
+	return "", false
+}
+
+// validatable reports whether values of type t have a Validate method,
+// either hand-written or generated by this run.
+func (g *generator) validatable(t types.Type) bool {
+	named, ok := t.(*types.Named)
+	if !ok {
+		return false
+	}
+	return g.targets[named] || hasValidate(named)
+}
+
+// isStruct reports whether t is a struct type or a pointer to one.
+func isStruct(t types.Type) bool {
+	if p, ok := t.(*types.Pointer); ok {
+		t = p.Elem()
+	}
+	_, ok := t.Underlying().(*types.Struct)
+	return ok
+}
+
+// indentLines prefixes every line of code with indent.
+func indentLines(code, indent string) string {
+	lines := strings.SplitAfter(code, "\n")
+	var b strings.Builder
+	for _, line := range lines {
+		if line != "" {
+			b.WriteString(indent + line)
+		}
+	}
+	return b.String()
+}
+
+// structType returns obj as a named struct type.
+func structType(obj types.Object) (*types.Named, bool) {
+	tn, ok := obj.(*types.TypeName)
+	if !ok || tn.IsAlias() {
+		return nil, false
+	}
+	named, ok := tn.Type().(*types.Named)
+	if !ok || named.TypeParams().Len() > 0 {
+		return nil, false
+	}
+	_, ok = named.Underlying().(*types.Struct)
+	return named, ok
+}
+
+// hasTags reports whether a field of named has a validate tag.
+func hasTags(named *types.Named) bool {
+	st := named.Underlying().(*types.Struct)
+	for i := 0; i < st.NumFields(); i++ {
+		if _, ok := reflect.StructTag(st.Tag(i)).Lookup(validator.TagName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// hasValidate reports whether named, or a pointer to it, has a Validate method.
+func hasValidate(named *types.Named) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(named), true, named.Obj().Pkg(), "Validate")
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+// isStd reports whether path is the import path of a standard library package.
+func isStd(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
+
+// builtin looks up a built-in rule by name.
+func builtin(name string) (validator.Rule, bool) {
+	for _, r := range validator.BuiltinRules() {
+		if r.Name == name {
+			return r, true
+		}
+	}
+	return validator.Rule{}, false
+}
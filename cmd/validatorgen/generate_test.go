@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/solrac97gr/validator"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files of testdata")
+
+// TestGenerate compares the output of the generator with the
+// validate_gen.go golden file of each package of testdata. The parity
+// tests compile the same files, so they must be kept up to date:
+//
+//	go test ./cmd/validatorgen -update
+func TestGenerate(t *testing.T) {
+	dirs, err := filepath.Glob(filepath.Join("testdata", "*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, dir := range dirs {
+		t.Run(filepath.Base(dir), func(t *testing.T) {
+			golden := filepath.Join(dir, "validate_gen.go")
+			got, err := generate(dir, golden, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if line, got, want, ok := firstDiff(got, want); !ok {
+				t.Errorf("%s:%d differs from the generated code, run go test -update if this is expected:\n got: %s\nwant: %s", golden, line, got, want)
+			}
+		})
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want error
+	}{
+		{"unknown rule", "type T struct {\n\tA string `validate:\"nope\"`\n}", validator.ErrUnknownRule},
+		{"not applicable", "type T struct {\n\tA bool `validate:\"email\"`\n}", validator.ErrNotApplicable},
+		{"bad param", "type T struct {\n\tA int `validate:\"max=ten\"`\n}", validator.ErrInvalidParam},
+		{"missing field", "type T struct {\n\tA int `validate:\"eqfield=B\"`\n}", validator.ErrInvalidParam},
+		{"field types", "type T struct {\n\tA int `validate:\"eqfield=B\"`\n\tB string\n}", validator.ErrNotApplicable},
+		// Bounds must be constants of the compared type for the code to compile.
+		{"fractional bound", "type T struct {\n\tA int `validate:\"min=1.5\"`\n}", validator.ErrInvalidParam},
+		{"fractional length", "type T struct {\n\tA []string `validate:\"max=2.5\"`\n}", validator.ErrInvalidParam},
+		{"negative unsigned", "type T struct {\n\tA uint `validate:\"gte=-1\"`\n}", validator.ErrInvalidParam},
+		{"overflow", "type T struct {\n\tA int8 `validate:\"lt=300\"`\n}", validator.ErrInvalidParam},
+		{"infinity", "type T struct {\n\tA float64 `validate:\"max=Inf\"`\n}", validator.ErrInvalidParam},
+		{"fractional choice", "type T struct {\n\tA int `validate:\"oneof=1 2.5\"`\n}", validator.ErrInvalidParam},
+		{"map keys", "type T struct {\n\tA string `validate:\"required\"`\n\tB map[bool]U\n}\n\ntype U struct {\n\tC string `validate:\"required\"`\n}", validator.ErrNotApplicable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/p\n\ngo 1.21\n")
+			writeFile(t, filepath.Join(dir, "types.go"), "package p\n\n"+tt.src+"\n")
+			_, err := generate(dir, filepath.Join(dir, "validate_gen.go"), nil)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("generate() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// firstDiff returns the first line that differs between a and b, and
+// reports whether they are equal.
+func firstDiff(a, b []byte) (line int, la, lb string, equal bool) {
+	linesA, linesB := bytes.Split(a, []byte("\n")), bytes.Split(b, []byte("\n"))
+	for i := 0; i < len(linesA) || i < len(linesB); i++ {
+		var x, y []byte
+		if i < len(linesA) {
+			x = linesA[i]
+		}
+		if i < len(linesB) {
+			y = linesB[i]
+		}
+		if i >= len(linesA) || i >= len(linesB) || !bytes.Equal(x, y) {
+			return i + 1, string(x), string(y), false
+		}
+	}
+	return 0, "", "", true
+}
+
+func writeFile(t *testing.T, name, content string) {
+	t.Helper()
+	if err := os.WriteFile(name, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
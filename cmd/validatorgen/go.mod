@@ -0,0 +1,18 @@
+module github.com/solrac97gr/validator/cmd/validatorgen
+
+go 1.26.0
+
+require (
+	github.com/solrac97gr/validator v0.0.0-00010101000000-000000000000
+	golang.org/x/tools v0.51.0
+)
+
+require (
+	github.com/robfig/cron v1.2.0 // indirect
+	golang.org/x/mod v0.41.0 // indirect
+	golang.org/x/net v0.59.0 // indirect
+	golang.org/x/sync v0.23.0 // indirect
+	golang.org/x/text v0.42.0 // indirect
+)
+
+replace github.com/solrac97gr/validator => ../..
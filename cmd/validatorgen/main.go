@@ -0,0 +1,72 @@
+// Command validatorgen generates reflection-free Validate methods from the
+// validate tags of the structs in a package.
+//
+// It is a module of its own, so that its dependencies don't raise the Go
+// version required by the validator. Add it as a tool, then a directive to
+// the package, and run go generate:
+//
+//	go get -tool github.com/solrac97gr/validator/cmd/validatorgen
+//
+//	//go:generate go tool validatorgen
+//
+// Every struct with validate tags and no hand-written Validate method gets
+// one, satisfying validator.EvaluableStruct. The generated methods call the
+// functions of the validations package directly. With -check, nothing is
+// written and the command fails when the generated file is out of date.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	var (
+		output = flag.String("output", "validate_gen.go", "name of the generated file, relative to the package directory")
+		types  = flag.String("type", "", "comma-separated list of types to generate; defaults to every struct with validate tags")
+		check  = flag.Bool("check", false, "fail if the generated file is out of date instead of writing it")
+	)
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: validatorgen [flags] [package directory]\n")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	dir := "."
+	if flag.NArg() > 0 {
+		dir = flag.Arg(0)
+	}
+	var names []string
+	if *types != "" {
+		names = strings.Split(*types, ",")
+	}
+
+	if err := run(dir, *output, names, *check); err != nil {
+		fmt.Fprintln(os.Stderr, "validatorgen:", err)
+		os.Exit(1)
+	}
+}
+
+// run generates the Validate methods of the package in dir.
+func run(dir, output string, types []string, check bool) error {
+	path := filepath.Join(dir, output)
+	src, err := generate(dir, path, types)
+	if err != nil {
+		return err
+	}
+	if !check {
+		return os.WriteFile(path, src, 0o644)
+	}
+	current, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if !bytes.Equal(current, src) {
+		return fmt.Errorf("%s is out of date, run go generate", path)
+	}
+	return nil
+}
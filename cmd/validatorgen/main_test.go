@@ -0,0 +1,54 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunCheck(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "go.mod"), "module example.com/p\n\ngo 1.21\n")
+	writeFile(t, filepath.Join(dir, "types.go"), "package p\n\ntype T struct {\n\tA string `validate:\"required\"`\n}\n")
+	output := filepath.Join(dir, "validate_gen.go")
+
+	if err := run(dir, "validate_gen.go", nil, true); err == nil || !strings.Contains(err.Error(), "out of date") {
+		t.Fatalf("run() without a generated file = %v, want out of date", err)
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Fatalf("run() with check wrote %s", output)
+	}
+	if err := run(dir, "validate_gen.go", nil, false); err != nil {
+		t.Fatalf("run() = %v", err)
+	}
+	if err := run(dir, "validate_gen.go", nil, true); err != nil {
+		t.Fatalf("run() after generating = %v, want nil", err)
+	}
+
+	// Editing the types or the generated file makes it out of date.
+	generated, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(dir, "types.go"), "package p\n\ntype T struct {\n\tA string `validate:\"required,email\"`\n}\n")
+	if err := run(dir, "validate_gen.go", nil, true); err == nil {
+		t.Error("run() after editing the types = nil, want out of date")
+	}
+	if current, err := os.ReadFile(output); err != nil || string(current) != string(generated) {
+		t.Errorf("run() with check changed %s", output)
+	}
+	if err := run(dir, "validate_gen.go", nil, false); err != nil {
+		t.Fatalf("run() = %v", err)
+	}
+	writeFile(t, output, "// edited\n")
+	if err := run(dir, "validate_gen.go", nil, true); err == nil {
+		t.Error("run() after editing the generated file = nil, want out of date")
+	}
+
+	// Generation errors are reported in check mode too.
+	writeFile(t, filepath.Join(dir, "types.go"), "package p\n\ntype T struct {\n\tA string `validate:\"nope\"`\n}\n")
+	if err := run(dir, "validate_gen.go", nil, true); err == nil || strings.Contains(err.Error(), "out of date") {
+		t.Errorf("run() with an unknown rule = %v, want a generation error", err)
+	}
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/solrac97gr/validator"
+	"github.com/solrac97gr/validator/cmd/validatorgen/testdata/basic"
+	"github.com/solrac97gr/validator/cmd/validatorgen/testdata/groups"
+)
+
+// The reflected types have the fields and tags of the generated ones but
+// none of their methods, so the validator reads their tags by reflection.
+type (
+	reflectedUser    basic.User
+	reflectedEvent   basic.Event
+	reflectedTrip    basic.Trip
+	reflectedAccount groups.Account
+)
+
+func (reflectedUser) Validate(...interface{}) error    { return nil }
+func (reflectedEvent) Validate(...interface{}) error   { return nil }
+func (reflectedTrip) Validate(...interface{}) error    { return nil }
+func (reflectedAccount) Validate(...interface{}) error { return nil }
+
+// groupSets are the groups every input is validated against; nil stands
+// for Struct.
+var groupSets = [][]string{
+	nil,
+	{validator.DefaultGroup},
+	{"create"},
+	{"update"},
+	{validator.DefaultGroup, "create"},
+	{validator.DefaultGroup, "update"},
+}
+
+// TestParity validates the same values with the generated methods and by
+// reflection, and requires the same errors.
+func TestParity(t *testing.T) {
+	nick, bad := "nick", "n!ck"
+	validUser := basic.User{
+		Name: "Ada", Email: "ada@example.com", Nickname: &nick, Code: "ABCD", Role: "admin",
+		Age: 36, Score: 1.5, Even: 2, Tags: []string{"a", "b"}, Website: "https://example.com",
+		IP: "10.0.0.1", Port: 8080, Password: "correcthorse", Confirm: "correcthorse",
+	}
+	users := map[string]basic.User{
+		"valid": validUser,
+		"zero":  {},
+		"invalid": {
+			Name: "A1", Email: "ada", Nickname: &bad, Code: "abc", Role: "root", Age: 12, Score: -1,
+			Even: 3, Tags: []string{"a", "a"}, Website: "example", IP: "10.0.0", Port: 70000,
+			Password: "short", Confirm: "other",
+		},
+		"bounds": {
+			Name: "Abcdefghijk", Email: "a@b.co", Code: "ABCDE", Role: "user", Age: 131, Score: 0,
+			Tags: []string{"a", "b", "c", "d"}, Password: "longenough", Confirm: "longenough",
+		},
+	}
+
+	start := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC)
+	venue := basic.Address{City: "Paris", Country: "FR"}
+	events := map[string]basic.Event{
+		"valid": {Start: start, End: start.Add(time.Hour), Venue: venue, Stops: []*basic.Address{&venue, nil}, Contact: &venue},
+		"zero":  {},
+		"nested": {
+			Start: start, End: start, Venue: basic.Address{City: "P4ris", Country: "FRA"},
+			Stops:   []*basic.Address{{City: "Lyon", Country: "F"}, nil, {}},
+			Contact: &basic.Address{Country: "FR"},
+		},
+	}
+
+	// Maps hold a single invalid struct each, as their order is random.
+	invalid := basic.Address{City: "P4ris", Country: "FRA"}
+	trips := map[string]basic.Trip{
+		"valid": {
+			Name: "Tour", Legs: [2]basic.Address{venue, venue}, Days: [][]*basic.Address{{&venue, nil}, nil},
+			ByCode: map[basic.Code]basic.Address{"PAR": venue}, ByNumber: map[int]*basic.Address{1: &venue, 2: nil},
+			Groups: map[string][]basic.Address{"a": {venue}},
+		},
+		"zero": {},
+		"nested": {
+			Legs: [2]basic.Address{venue, invalid}, Days: [][]*basic.Address{{&venue}, {nil, &invalid, {}}},
+			ByCode: map[basic.Code]basic.Address{"PAR": venue, "BAD": invalid}, ByNumber: map[int]*basic.Address{-7: &invalid, 2: nil},
+			Groups: map[string][]basic.Address{"a.b": {venue, invalid}, "c": nil},
+		},
+	}
+
+	accounts := map[string]groups.Account{
+		"valid create": {Name: "Ada", Limit: 50, Address: groups.Address{City: "Paris"}},
+		"valid update": {ID: "a1", Name: "Ada", Email: "ada@example.com", Limit: 50},
+		"zero":         {},
+		"invalid": {
+			ID: "toolongid", Name: "Ad4", Email: "ada", Limit: 500,
+			Address: groups.Address{City: "P4ris"},
+		},
+		"low limit": {ID: "a1", Name: "Ada", Limit: 5, Address: groups.Address{City: "Paris"}},
+	}
+
+	v := validator.NewValidator()
+	for name, u := range users {
+		compare(t, v, "User/"+name, u, reflectedUser(u))
+	}
+	for name, e := range events {
+		compare(t, v, "Event/"+name, e, reflectedEvent(e))
+	}
+	for name, tr := range trips {
+		compare(t, v, "Trip/"+name, tr, reflectedTrip(tr))
+	}
+	for name, a := range accounts {
+		compare(t, v, "Account/"+name, a, reflectedAccount(a))
+	}
+}
+
+// compare validates generated and reflected against every set of groups.
+func compare(t *testing.T, v *validator.ValidatorImpl, name string, generated, reflected validator.EvaluableStruct) {
+	t.Helper()
+	if _, ok := generated.(validator.GeneratedStruct); !ok {
+		t.Fatalf("%s: %T has no generated method", name, generated)
+	}
+	for _, set := range groupSets {
+		var gotGen, gotRefl error
+		if set == nil {
+			gotGen, gotRefl = v.Struct(generated), v.Struct(reflected)
+		} else {
+			gotGen, gotRefl = v.StructGroups(generated, set...), v.StructGroups(reflected, set...)
+		}
+		gen, refl := describe(gotGen), describe(gotRefl)
+		if !reflect.DeepEqual(gen, refl) {
+			t.Errorf("%s %v:\ngenerated:  %q\nreflection: %q", name, set, gen, refl)
+		}
+	}
+}
+
+// describe lists the field, rule, parameter and message of every failure
+// of err.
+func describe(err error) []string {
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		if err != nil {
+			return []string{err.Error()}
+		}
+		return nil
+	}
+	lines := make([]string, len(errs))
+	for i, fe := range errs {
+		lines[i] = fmt.Sprintf("%s %s=%s: %v", fe.Field, fe.Rule, fe.Param, fe.Err)
+	}
+	return lines
+}
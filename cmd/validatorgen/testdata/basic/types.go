@@ -0,0 +1,61 @@
+// Package basic is the input of the generator tests for structs without
+// validation groups.
+package basic
+
+import "time"
+
+//go:generate go run github.com/solrac97gr/validator/cmd/validatorgen
+
+// Code is a string type of its own.
+type Code string
+
+// User exercises the rules on strings, numbers, pointers and slices.
+type User struct {
+	Name     string   `validate:"required,alpha,min=2,max=10"`
+	Email    string   `validate:"required,email"`
+	Nickname *string  `validate:"omitempty,alphanum"`
+	Code     Code     `validate:"len=4,uppercase"`
+	Role     string   `validate:"oneof=admin user"`
+	Age      int      `validate:"gte=18,lte=130"`
+	Score    float64  `validate:"positive"`
+	Even     int      `validate:"even"`
+	Tags     []string `validate:"max=3,unique"`
+	Website  string   `validate:"omitempty,url"`
+	IP       string   `validate:"omitempty,ip"`
+	Port     int      `validate:"omitempty,port"`
+	Password string   `validate:"required,min=8"`
+	Confirm  string   `validate:"eqfield=Password"`
+	internal string
+}
+
+// Event compares times and nests structs.
+type Event struct {
+	Start   time.Time `validate:"required"`
+	End     time.Time `validate:"gtfield=Start"`
+	Venue   Address   `validate:"required"`
+	Stops   []*Address
+	Contact *Address
+}
+
+// Address is nested in Event.
+type Address struct {
+	City    string `validate:"required,alpha"`
+	Country string `validate:"len=2"`
+}
+
+// Trip nests structs in arrays, slices of slices and maps, and embeds an
+// unexported struct.
+type Trip struct {
+	schedule
+	Name     string `validate:"required"`
+	Legs     [2]Address
+	Days     [][]*Address
+	ByCode   map[Code]Address
+	ByNumber map[int]*Address
+	Groups   map[string][]Address
+}
+
+// schedule is embedded in Trip.
+type schedule struct {
+	Season string `validate:"omitempty,oneof=summer winter"`
+}
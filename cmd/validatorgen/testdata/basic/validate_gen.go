@@ -0,0 +1,295 @@
+// Code generated by validatorgen. DO NOT EDIT.
+
+package basic
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/solrac97gr/validator"
+	"github.com/solrac97gr/validator/validations"
+)
+
+// Validate validates the fields of Address according to their validate tags.
+func (s Address) Validate(args ...interface{}) error {
+	var errs validator.ValidationErrors
+	groups := validator.GroupsFromArgs(args...)
+	// City
+	if groups.Has("default") {
+		if s.City == "" {
+			errs = append(errs, &validator.FieldError{Field: "City", Rule: "required", Err: validator.ErrRequired})
+		} else if err := validations.StringIsAlpha(s.City); err != nil {
+			errs = append(errs, &validator.FieldError{Field: "City", Rule: "alpha", Err: err})
+		}
+	}
+	// Country
+	if groups.Has("default") {
+		if !(utf8.RuneCountInString(s.Country) == 2) {
+			errs = append(errs, &validator.FieldError{Field: "Country", Rule: "len", Param: "2", Err: fmt.Errorf("%w 2", validator.ErrLen)})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidatorGenerated marks Address as validated by generated code.
+func (Address) ValidatorGenerated() interface{} { return (*Address)(nil) }
+
+// Validate validates the fields of Event according to their validate tags.
+func (s Event) Validate(args ...interface{}) error {
+	var errs validator.ValidationErrors
+	groups := validator.GroupsFromArgs(args...)
+	var n int
+	// Start
+	if groups.Has("default") {
+		if s.Start == (time.Time{}) {
+			errs = append(errs, &validator.FieldError{Field: "Start", Rule: "required", Err: validator.ErrRequired})
+		}
+	}
+	// End
+	if groups.Has("default") {
+		if !(s.End.Compare(s.Start) > 0) {
+			errs = append(errs, &validator.FieldError{Field: "End", Rule: "gtfield", Param: "Start", Err: fmt.Errorf("%w Start", validator.ErrGt)})
+		}
+	}
+	// Venue
+	n = len(errs)
+	if groups.Has("default") {
+		if s.Venue == (Address{}) {
+			errs = append(errs, &validator.FieldError{Field: "Venue", Rule: "required", Err: validator.ErrRequired})
+		}
+	}
+	if len(errs) == n {
+		if err := s.Venue.Validate(args...); err != nil {
+			errs = append(errs, validator.NestErrors("Venue", err)...)
+		}
+	}
+	// Stops
+	for i, e := range s.Stops {
+		if e != nil {
+			if err := e.Validate(args...); err != nil {
+				errs = append(errs, validator.NestErrors("Stops["+strconv.Itoa(i)+"]", err)...)
+			}
+		}
+	}
+	// Contact
+	if s.Contact != nil {
+		if err := s.Contact.Validate(args...); err != nil {
+			errs = append(errs, validator.NestErrors("Contact", err)...)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidatorGenerated marks Event as validated by generated code.
+func (Event) ValidatorGenerated() interface{} { return (*Event)(nil) }
+
+// Validate validates the fields of Trip according to their validate tags.
+func (s Trip) Validate(args ...interface{}) error {
+	var errs validator.ValidationErrors
+	groups := validator.GroupsFromArgs(args...)
+	// schedule
+	if err := s.schedule.Validate(args...); err != nil {
+		errs = append(errs, validator.NestErrors("schedule", err)...)
+	}
+	// Name
+	if groups.Has("default") {
+		if s.Name == "" {
+			errs = append(errs, &validator.FieldError{Field: "Name", Rule: "required", Err: validator.ErrRequired})
+		}
+	}
+	// Legs
+	for i, e := range s.Legs {
+		if err := e.Validate(args...); err != nil {
+			errs = append(errs, validator.NestErrors("Legs["+strconv.Itoa(i)+"]", err)...)
+		}
+	}
+	// Days
+	for i, e := range s.Days {
+		for i1, e1 := range e {
+			if e1 != nil {
+				if err := e1.Validate(args...); err != nil {
+					errs = append(errs, validator.NestErrors("Days["+strconv.Itoa(i)+"]["+strconv.Itoa(i1)+"]", err)...)
+				}
+			}
+		}
+	}
+	// ByCode
+	for k, e := range s.ByCode {
+		if err := e.Validate(args...); err != nil {
+			errs = append(errs, validator.NestErrors("ByCode["+string(k)+"]", err)...)
+		}
+	}
+	// ByNumber
+	for k, e := range s.ByNumber {
+		if e != nil {
+			if err := e.Validate(args...); err != nil {
+				errs = append(errs, validator.NestErrors("ByNumber["+strconv.FormatFloat(float64(k), 'f', -1, 64)+"]", err)...)
+			}
+		}
+	}
+	// Groups
+	for k, e := range s.Groups {
+		for i1, e1 := range e {
+			if err := e1.Validate(args...); err != nil {
+				errs = append(errs, validator.NestErrors("Groups["+k+"]["+strconv.Itoa(i1)+"]", err)...)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidatorGenerated marks Trip as validated by generated code.
+func (Trip) ValidatorGenerated() interface{} { return (*Trip)(nil) }
+
+// Validate validates the fields of User according to their validate tags.
+func (s User) Validate(args ...interface{}) error {
+	var errs validator.ValidationErrors
+	groups := validator.GroupsFromArgs(args...)
+	// Name
+	if groups.Has("default") {
+		if s.Name == "" {
+			errs = append(errs, &validator.FieldError{Field: "Name", Rule: "required", Err: validator.ErrRequired})
+		} else if err := validations.StringIsAlpha(s.Name); err != nil {
+			errs = append(errs, &validator.FieldError{Field: "Name", Rule: "alpha", Err: err})
+		} else if !(utf8.RuneCountInString(s.Name) >= 2) {
+			errs = append(errs, &validator.FieldError{Field: "Name", Rule: "min", Param: "2", Err: fmt.Errorf("%w 2", validator.ErrMin)})
+		} else if !(utf8.RuneCountInString(s.Name) <= 10) {
+			errs = append(errs, &validator.FieldError{Field: "Name", Rule: "max", Param: "10", Err: fmt.Errorf("%w 10", validator.ErrMax)})
+		}
+	}
+	// Email
+	if groups.Has("default") {
+		if s.Email == "" {
+			errs = append(errs, &validator.FieldError{Field: "Email", Rule: "required", Err: validator.ErrRequired})
+		} else if err := validations.IsValidEmail(s.Email); err != nil {
+			errs = append(errs, &validator.FieldError{Field: "Email", Rule: "email", Err: err})
+		}
+	}
+	// Nickname
+	if groups.Has("default") {
+		if s.Nickname != nil && !(*s.Nickname == "") {
+			if err := validations.StringIsAlphanumeric(*s.Nickname); err != nil {
+				errs = append(errs, &validator.FieldError{Field: "Nickname", Rule: "alphanum", Err: err})
+			}
+		}
+	}
+	// Code
+	if groups.Has("default") {
+		if !(utf8.RuneCountInString(string(s.Code)) == 4) {
+			errs = append(errs, &validator.FieldError{Field: "Code", Rule: "len", Param: "4", Err: fmt.Errorf("%w 4", validator.ErrLen)})
+		} else if err := validations.StringIsUpperCase(string(s.Code)); err != nil {
+			errs = append(errs, &validator.FieldError{Field: "Code", Rule: "uppercase", Err: err})
+		}
+	}
+	// Role
+	if groups.Has("default") {
+		if s.Role != "admin" && s.Role != "user" {
+			errs = append(errs, &validator.FieldError{Field: "Role", Rule: "oneof", Param: "admin user", Err: fmt.Errorf("%w admin user", validator.ErrOneOf)})
+		}
+	}
+	// Age
+	if groups.Has("default") {
+		if !(s.Age >= 18) {
+			errs = append(errs, &validator.FieldError{Field: "Age", Rule: "gte", Param: "18", Err: fmt.Errorf("%w 18", validator.ErrMin)})
+		} else if !(s.Age <= 130) {
+			errs = append(errs, &validator.FieldError{Field: "Age", Rule: "lte", Param: "130", Err: fmt.Errorf("%w 130", validator.ErrMax)})
+		}
+	}
+	// Score
+	if groups.Has("default") {
+		if !(s.Score > 0) {
+			errs = append(errs, &validator.FieldError{Field: "Score", Rule: "positive", Err: validator.ErrNotPositive})
+		}
+	}
+	// Even
+	if groups.Has("default") {
+		if !validations.IsEven(int(s.Even)) {
+			errs = append(errs, &validator.FieldError{Field: "Even", Rule: "even", Err: validator.ErrNotEven})
+		}
+	}
+	// Tags
+	if groups.Has("default") {
+		if !(len(s.Tags) <= 3) {
+			errs = append(errs, &validator.FieldError{Field: "Tags", Rule: "max", Param: "3", Err: fmt.Errorf("%w 3", validator.ErrMax)})
+		} else if !validations.SliceIsUnique(s.Tags, func(e string) interface{} { return e }) {
+			errs = append(errs, &validator.FieldError{Field: "Tags", Rule: "unique", Err: validator.ErrNotUnique})
+		}
+	}
+	// Website
+	if groups.Has("default") {
+		if !(s.Website == "") {
+			if err := validations.ValidateURL(s.Website); err != nil {
+				errs = append(errs, &validator.FieldError{Field: "Website", Rule: "url", Err: err})
+			}
+		}
+	}
+	// IP
+	if groups.Has("default") {
+		if !(s.IP == "") {
+			if err := validations.ValidateIPAddress(s.IP); err != nil {
+				errs = append(errs, &validator.FieldError{Field: "IP", Rule: "ip", Err: err})
+			}
+		}
+	}
+	// Port
+	if groups.Has("default") {
+		if !(s.Port == 0) {
+			if err := validations.ValidatePortNumber(int(s.Port), validations.PortOptions{}); err != nil {
+				errs = append(errs, &validator.FieldError{Field: "Port", Rule: "port", Err: err})
+			}
+		}
+	}
+	// Password
+	if groups.Has("default") {
+		if s.Password == "" {
+			errs = append(errs, &validator.FieldError{Field: "Password", Rule: "required", Err: validator.ErrRequired})
+		} else if !(utf8.RuneCountInString(s.Password) >= 8) {
+			errs = append(errs, &validator.FieldError{Field: "Password", Rule: "min", Param: "8", Err: fmt.Errorf("%w 8", validator.ErrMin)})
+		}
+	}
+	// Confirm
+	if groups.Has("default") {
+		if !(s.Confirm == s.Password) {
+			errs = append(errs, &validator.FieldError{Field: "Confirm", Rule: "eqfield", Param: "Password", Err: fmt.Errorf("%w Password", validator.ErrEq)})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidatorGenerated marks User as validated by generated code.
+func (User) ValidatorGenerated() interface{} { return (*User)(nil) }
+
+// Validate validates the fields of schedule according to their validate tags.
+func (s schedule) Validate(args ...interface{}) error {
+	var errs validator.ValidationErrors
+	groups := validator.GroupsFromArgs(args...)
+	// Season
+	if groups.Has("default") {
+		if !(s.Season == "") {
+			if s.Season != "summer" && s.Season != "winter" {
+				errs = append(errs, &validator.FieldError{Field: "Season", Rule: "oneof", Param: "summer winter", Err: fmt.Errorf("%w summer winter", validator.ErrOneOf)})
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidatorGenerated marks schedule as validated by generated code.
+func (schedule) ValidatorGenerated() interface{} { return (*schedule)(nil) }
@@ -0,0 +1,20 @@
+// Package groups is the input of the generator tests for structs with
+// validation groups.
+package groups
+
+//go:generate go run github.com/solrac97gr/validator/cmd/validatorgen
+
+// Account has rules for the create and update groups.
+type Account struct {
+	ID      string  `validate:"create:isdefault;update:required;max=8"`
+	Name    string  `validate:"create|update:required;alpha"`
+	Email   string  `validate:"update:omitempty,email"`
+	Limit   int     `validate:"create:gte=10;max=100"`
+	Address Address `validate:"create:required"`
+}
+
+// Address has default rules only, which run when the default group is
+// validated.
+type Address struct {
+	City string `validate:"required,alpha"`
+}
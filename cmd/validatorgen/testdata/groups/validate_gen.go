@@ -0,0 +1,112 @@
+// Code generated by validatorgen. DO NOT EDIT.
+
+package groups
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/solrac97gr/validator"
+	"github.com/solrac97gr/validator/validations"
+)
+
+// Validate validates the fields of Account according to their validate tags.
+func (s Account) Validate(args ...interface{}) error {
+	var errs validator.ValidationErrors
+	groups := validator.GroupsFromArgs(args...)
+	var n int
+	// ID
+	n = len(errs)
+	if groups.Has("default") {
+		if !(utf8.RuneCountInString(s.ID) <= 8) {
+			errs = append(errs, &validator.FieldError{Field: "ID", Rule: "max", Param: "8", Err: fmt.Errorf("%w 8", validator.ErrMax)})
+		}
+	}
+	if len(errs) == n && groups.Has("create") {
+		if !(s.ID == "") {
+			errs = append(errs, &validator.FieldError{Field: "ID", Rule: "isdefault", Err: validator.ErrNotDefault})
+		}
+	}
+	if len(errs) == n && groups.Has("update") {
+		if s.ID == "" {
+			errs = append(errs, &validator.FieldError{Field: "ID", Rule: "required", Err: validator.ErrRequired})
+		}
+	}
+	// Name
+	n = len(errs)
+	if groups.Has("default") {
+		if err := validations.StringIsAlpha(s.Name); err != nil {
+			errs = append(errs, &validator.FieldError{Field: "Name", Rule: "alpha", Err: err})
+		}
+	}
+	if len(errs) == n && groups.Has("create") {
+		if s.Name == "" {
+			errs = append(errs, &validator.FieldError{Field: "Name", Rule: "required", Err: validator.ErrRequired})
+		}
+	}
+	if len(errs) == n && groups.Has("update") {
+		if s.Name == "" {
+			errs = append(errs, &validator.FieldError{Field: "Name", Rule: "required", Err: validator.ErrRequired})
+		}
+	}
+	// Email
+	if groups.Has("update") {
+		if !(s.Email == "") {
+			if err := validations.IsValidEmail(s.Email); err != nil {
+				errs = append(errs, &validator.FieldError{Field: "Email", Rule: "email", Err: err})
+			}
+		}
+	}
+	// Limit
+	n = len(errs)
+	if groups.Has("default") {
+		if !(s.Limit <= 100) {
+			errs = append(errs, &validator.FieldError{Field: "Limit", Rule: "max", Param: "100", Err: fmt.Errorf("%w 100", validator.ErrMax)})
+		}
+	}
+	if len(errs) == n && groups.Has("create") {
+		if !(s.Limit >= 10) {
+			errs = append(errs, &validator.FieldError{Field: "Limit", Rule: "gte", Param: "10", Err: fmt.Errorf("%w 10", validator.ErrMin)})
+		}
+	}
+	// Address
+	n = len(errs)
+	if groups.Has("create") {
+		if s.Address == (Address{}) {
+			errs = append(errs, &validator.FieldError{Field: "Address", Rule: "required", Err: validator.ErrRequired})
+		}
+	}
+	if len(errs) == n {
+		if err := s.Address.Validate(args...); err != nil {
+			errs = append(errs, validator.NestErrors("Address", err)...)
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidatorGenerated marks Account as validated by generated code.
+func (Account) ValidatorGenerated() interface{} { return (*Account)(nil) }
+
+// Validate validates the fields of Address according to their validate tags.
+func (s Address) Validate(args ...interface{}) error {
+	var errs validator.ValidationErrors
+	groups := validator.GroupsFromArgs(args...)
+	// City
+	if groups.Has("default") {
+		if s.City == "" {
+			errs = append(errs, &validator.FieldError{Field: "City", Rule: "required", Err: validator.ErrRequired})
+		} else if err := validations.StringIsAlpha(s.City); err != nil {
+			errs = append(errs, &validator.FieldError{Field: "City", Rule: "alpha", Err: err})
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// ValidatorGenerated marks Address as validated by generated code.
+func (Address) ValidatorGenerated() interface{} { return (*Address)(nil) }
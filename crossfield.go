@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// crossRule compares a value with another one: a sibling field named by
+// the parameter in structs, or the other value given to VarWithValue.
+type crossRule struct {
+	err error
+	ok  func(cmp int) bool
+}
+
+var crossRules = map[string]crossRule{
+	"eqfield":  {ErrEq, func(c int) bool { return c == 0 }},
+	"nefield":  {ErrNe, func(c int) bool { return c != 0 }},
+	"gtfield":  {ErrGt, func(c int) bool { return c > 0 }},
+	"gtefield": {ErrMin, func(c int) bool { return c >= 0 }},
+	"ltfield":  {ErrLt, func(c int) bool { return c < 0 }},
+	"ltefield": {ErrMax, func(c int) bool { return c <= 0 }},
+}
+
+// crossKinds lists the kinds cross-value rules apply to. Structs are
+// accepted for time.Time.
+var crossKinds = append(append([]reflect.Kind{}, sizeKinds...), reflect.Bool, reflect.Struct)
+
+// otherFunc resolves the value a cross-value rule compares with from the
+// rule's parameter. It returns an invalid value when there is none.
+type otherFunc func(param string) reflect.Value
+
+// fieldOf returns an otherFunc resolving dotted field paths in the struct rv.
+func fieldOf(rv reflect.Value) otherFunc {
+	return func(param string) reflect.Value {
+		value := rv
+		for _, name := range strings.Split(param, ".") {
+			value = indirect(value)
+			if value.Kind() != reflect.Struct {
+				return reflect.Value{}
+			}
+			value = value.FieldByName(name)
+		}
+		return value
+	}
+}
+
+// crossField runs the cross-value rule r against value. label names the
+// other value in the error.
+func crossField(r crossRule, value, other reflect.Value, label string) error {
+	other = indirect(other)
+	if !other.IsValid() {
+		return fmt.Errorf("%w: no value to compare with", ErrNotApplicable)
+	}
+	cmp, ordered := compareValues(value, other)
+	if !ordered && r.err != ErrEq && r.err != ErrNe {
+		return fmt.Errorf("%w: cannot order %s and %s", ErrNotApplicable, value.Type(), other.Type())
+	}
+	if !r.ok(cmp) {
+		return fmt.Errorf("%w %s", r.err, label)
+	}
+	return nil
+}
+
+// compareValues compares two values, returning -1, 0 or 1. Numbers and
+// times are compared by value and strings lexically. Other values can only
+// be compared for equality, which is reported with 0 or 1 and ordered set
+// to false.
+func compareValues(a, b reflect.Value) (cmp int, ordered bool) {
+	if !a.CanInterface() || !b.CanInterface() {
+		return 1, false
+	}
+	if ta, ok := a.Interface().(time.Time); ok {
+		if tb, ok := b.Interface().(time.Time); ok {
+			return ta.Compare(tb), true
+		}
+	}
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return strings.Compare(a.String(), b.String()), true
+	}
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			switch {
+			case x < y:
+				return -1, true
+			case x > y:
+				return 1, true
+			}
+			return 0, true
+		}
+	}
+	if a.Type() == b.Type() && reflect.DeepEqual(a.Interface(), b.Interface()) {
+		return 0, false
+	}
+	return 1, false
+}
+
+// needsOther is the RuleFunc of cross-value rules used without a value to
+// compare with, such as in Map.
+func needsOther(reflect.Value, string) error {
+	return fmt.Errorf("%w: no value to compare with", ErrNotApplicable)
+}
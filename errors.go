@@ -0,0 +1,67 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+)
+
+// FieldError describes a single field that failed validation.
+type FieldError struct {
+	// Field is the path of the offending field, e.g. "Address.City".
+	Field string
+	// Rule is the name of the rule that failed, e.g. "ipv4".
+	Rule string
+	// Param is the parameter given to the rule, if any.
+	Param string
+	// Err is the error reported by the rule.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *FieldError) Error() string {
+	msg := "validation failed"
+	if e.Err != nil {
+		msg = e.Err.Error()
+	}
+	if e.Field == "" {
+		return msg
+	}
+	return e.Field + ": " + msg
+}
+
+// Unwrap returns the error reported by the rule.
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationErrors is returned when one or more fields fail validation.
+type ValidationErrors []*FieldError
+
+// Error implements the error interface.
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// NestErrors prefixes the field paths in err with field, as is done for the
+// fields of nested structs. An error that is not a ValidationErrors is
+// reported as a single FieldError for field.
+func NestErrors(field string, err error) ValidationErrors {
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		return ValidationErrors{{Field: field, Err: err}}
+	}
+	nested := make(ValidationErrors, len(errs))
+	for i, fe := range errs {
+		copied := *fe
+		copied.Field = field
+		if fe.Field != "" {
+			copied.Field = field + "." + fe.Field
+		}
+		nested[i] = &copied
+	}
+	return nested
+}
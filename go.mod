@@ -1,5 +1,15 @@
 module github.com/solrac97gr/validator
 
-go 1.19
+go 1.25.0
 
-require github.com/robfig/cron v1.2.0
+require (
+	github.com/robfig/cron v1.2.0
+	golang.org/x/net v0.57.0
+	golang.org/x/text v0.40.0
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260904194346-d0f1323225a4
+	google.golang.org/grpc v1.84.0
+	google.golang.org/protobuf v1.36.12
+	gopkg.in/yaml.v3 v3.0.1
+)
+
+require golang.org/x/sys v0.47.0 // indirect
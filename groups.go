@@ -0,0 +1,37 @@
+package validator
+
+// Groups is the list of groups being validated. StructGroups passes it to
+// the Validate method of the struct, which can read it back with
+// GroupsFromArgs.
+type Groups []string
+
+// Has reports whether the named group is being validated.
+func (g Groups) Has(name string) bool {
+	for _, group := range g {
+		if group == name {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupsFromArgs returns the groups passed to a Validate method. When the
+// struct is validated without groups, only DefaultGroup is returned.
+func GroupsFromArgs(args ...interface{}) Groups {
+	for _, arg := range args {
+		if g, ok := arg.(Groups); ok {
+			return g
+		}
+	}
+	return Groups{DefaultGroup}
+}
+
+// StructGroups validates the given struct against the rules of the named
+// groups only. Include DefaultGroup to also check the rules that don't
+// belong to a group. The groups are passed to the struct's Validate method
+// as a Groups value.
+//
+//	err := val.StructGroups(user, "create")
+func (v *ValidatorImpl) StructGroups(s EvaluableStruct, groups ...string) error {
+	return v.validateStruct(s, Groups(groups), Groups(groups))
+}
@@ -0,0 +1,151 @@
+// Package grpcvalidator provides gRPC server interceptors that validate
+// incoming request messages with a validator.Validator.
+package grpcvalidator
+
+import (
+	"context"
+	"errors"
+
+	"github.com/solrac97gr/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// validatable is implemented by messages generated with a plain
+// Validate() error method, such as the ones emitted by protoc-gen-validate.
+type validatable interface {
+	Validate() error
+}
+
+// validateFunc adapts a Validate() error method to validator.EvaluableStruct.
+type validateFunc func() error
+
+// Validate calls the wrapped function.
+func (f validateFunc) Validate(args ...interface{}) error {
+	return f()
+}
+
+// UnaryServerInterceptor returns a unary interceptor that validates every
+// request message before it reaches the handler.
+func UnaryServerInterceptor(val validator.Validator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if err := validate(val, req); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamServerInterceptor returns a stream interceptor that validates every
+// message received from the client.
+func StreamServerInterceptor(val validator.Validator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, val: val})
+	}
+}
+
+// serverStream wraps a grpc.ServerStream to validate received messages.
+type serverStream struct {
+	grpc.ServerStream
+	val validator.Validator
+}
+
+// RecvMsg receives a message and validates it.
+func (s *serverStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	return validate(s.val, m)
+}
+
+// validate runs msg through val if it can be evaluated, returning a
+// codes.InvalidArgument status when the message is invalid and a
+// codes.Internal status when the rules are misconfigured.
+func validate(val validator.Validator, msg interface{}) error {
+	var s validator.EvaluableStruct
+	switch m := msg.(type) {
+	case validator.EvaluableStruct:
+		s = m
+	case validatable:
+		s = validateFunc(m.Validate)
+	default:
+		return nil
+	}
+
+	err := val.Struct(s)
+	if err == nil {
+		return nil
+	}
+	return statusFromError(err).Err()
+}
+
+// misconfigured lists the errors reporting a mistake in the validation
+// rules rather than in the request.
+var misconfigured = []error{
+	validator.ErrUnknownRule,
+	validator.ErrInvalidParam,
+	validator.ErrNotApplicable,
+	validator.ErrUnknownMutator,
+	validator.ErrNotPointer,
+}
+
+// isMisconfigured reports whether err reports a mistake in the rules.
+func isMisconfigured(err error) bool {
+	for _, target := range misconfigured {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// misconfiguredMessage is the message of the statuses reporting
+// misconfigured rules, whose details are only meant for the server.
+const misconfiguredMessage = "request validation is misconfigured"
+
+// statusFromError converts a validation error into a status. Validation
+// failures, reported as validator.ValidationErrors or
+// *validator.FieldError, become codes.InvalidArgument with
+// errdetails.BadRequest field violations. Other errors returned by a
+// Validate method also become codes.InvalidArgument, without field
+// violations. Rules that are misconfigured become codes.Internal with a
+// generic message.
+func statusFromError(err error) *status.Status {
+	var fields validator.ValidationErrors
+	var field *validator.FieldError
+	switch {
+	case errors.As(err, &fields):
+	case errors.As(err, &field):
+		fields = validator.ValidationErrors{field}
+	case isMisconfigured(err):
+		return status.New(codes.Internal, misconfiguredMessage)
+	default:
+		return status.New(codes.InvalidArgument, err.Error())
+	}
+	for _, fe := range fields {
+		if isMisconfigured(fe.Err) {
+			return status.New(codes.Internal, misconfiguredMessage)
+		}
+	}
+
+	st := status.New(codes.InvalidArgument, err.Error())
+	br := &errdetails.BadRequest{}
+	for _, fe := range fields {
+		desc := "validation failed"
+		if fe.Err != nil {
+			desc = fe.Err.Error()
+		}
+		br.FieldViolations = append(br.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fe.Field,
+			Description: desc,
+		})
+	}
+
+	detailed, derr := st.WithDetails(br)
+	if derr != nil {
+		return st
+	}
+	return detailed
+}
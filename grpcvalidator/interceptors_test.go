@@ -0,0 +1,185 @@
+package grpcvalidator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/solrac97gr/validator"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// message is a request whose Validate method fails according to its value.
+type message struct {
+	*wrapperspb.StringValue
+}
+
+func newMessage() *message {
+	return &message{StringValue: &wrapperspb.StringValue{}}
+}
+
+func (m *message) Validate(args ...interface{}) error {
+	switch m.GetValue() {
+	case "":
+		return validator.ValidationErrors{{Field: "value", Rule: "required", Err: validator.ErrRequired}}
+	case "field":
+		return &validator.FieldError{Field: "value", Rule: "alpha", Err: errors.New("not alpha")}
+	case "unknown":
+		return validator.ValidationErrors{{Field: "value", Rule: "aplha", Err: validator.ErrUnknownRule}}
+	case "boom":
+		return errors.New("name must not be boom")
+	case "pointer":
+		return fmt.Errorf("checking message: %w", validator.ErrNotPointer)
+	}
+	return nil
+}
+
+var echoDesc = grpc.ServiceDesc{
+	ServiceName: "test.Echo",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Unary",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := newMessage()
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return req.(*message).StringValue, nil
+			}
+			if interceptor == nil {
+				return handler(ctx, in)
+			}
+			return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: "/test.Echo/Unary"}, handler)
+		},
+	}},
+	Streams: []grpc.StreamDesc{{
+		StreamName:    "Stream",
+		ServerStreams: true,
+		ClientStreams: true,
+		Handler: func(srv interface{}, stream grpc.ServerStream) error {
+			for {
+				in := newMessage()
+				if err := stream.RecvMsg(in); err == io.EOF {
+					return nil
+				} else if err != nil {
+					return err
+				}
+				if err := stream.SendMsg(in.StringValue); err != nil {
+					return err
+				}
+			}
+		},
+	}},
+}
+
+// dial starts a server with the interceptors on an in-memory listener and
+// returns a client connection to it.
+func dial(t *testing.T) *grpc.ClientConn {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	val := validator.NewValidator()
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(UnaryServerInterceptor(val)),
+		grpc.StreamInterceptor(StreamServerInterceptor(val)),
+	)
+	srv.RegisterService(&echoDesc, struct{}{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	conn := dial(t)
+	tests := []struct {
+		value      string
+		code       codes.Code
+		violations int
+	}{
+		{"valid", codes.OK, 0},
+		{"", codes.InvalidArgument, 1},
+		{"field", codes.InvalidArgument, 1},
+		{"unknown", codes.Internal, 0},
+		{"pointer", codes.Internal, 0},
+		{"boom", codes.InvalidArgument, 0},
+	}
+	for _, tt := range tests {
+		out := &wrapperspb.StringValue{}
+		err := conn.Invoke(context.Background(), "/test.Echo/Unary", wrapperspb.String(tt.value), out)
+		st := status.Convert(err)
+		if st.Code() != tt.code {
+			t.Errorf("%q: got code %v, want %v (%v)", tt.value, st.Code(), tt.code, err)
+			continue
+		}
+		if got := violations(st); got != tt.violations {
+			t.Errorf("%q: got %d field violations, want %d", tt.value, got, tt.violations)
+		}
+		// The details of misconfigured rules are not sent to clients.
+		if tt.code == codes.Internal && st.Message() != misconfiguredMessage {
+			t.Errorf("%q: got message %q, want %q", tt.value, st.Message(), misconfiguredMessage)
+		}
+		if tt.code == codes.OK && out.GetValue() != tt.value {
+			t.Errorf("%q: handler returned %q", tt.value, out.GetValue())
+		}
+	}
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	conn := dial(t)
+	desc := &echoDesc.Streams[0]
+	stream, err := conn.NewStream(context.Background(), desc, "/test.Echo/Stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := stream.SendMsg(wrapperspb.String("valid")); err != nil {
+		t.Fatal(err)
+	}
+	out := &wrapperspb.StringValue{}
+	if err := stream.RecvMsg(out); err != nil || out.GetValue() != "valid" {
+		t.Fatalf("valid message: got %q, %v", out.GetValue(), err)
+	}
+
+	if err := stream.SendMsg(wrapperspb.String("")); err != nil {
+		t.Fatal(err)
+	}
+	err = stream.RecvMsg(out)
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("invalid message: got code %v, want InvalidArgument (%v)", st.Code(), err)
+	}
+	if violations(st) != 1 {
+		t.Errorf("invalid message: got %d field violations, want 1", violations(st))
+	}
+}
+
+// violations returns the number of field violations in the details of st.
+func violations(st *status.Status) int {
+	n := 0
+	for _, d := range st.Details() {
+		if br, ok := d.(*errdetails.BadRequest); ok {
+			n += len(br.GetFieldViolations())
+		}
+	}
+	return n
+}
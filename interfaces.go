@@ -4,6 +4,16 @@ type EvaluableStruct interface {
 	Validate(args ...interface{}) error
 }
 
+// GeneratedStruct is implemented by structs whose Validate method was
+// generated by validatorgen from their validate tags. Struct calls the
+// generated method instead of reading the tags by reflection.
+// ValidatorGenerated returns a nil pointer to the generated type, telling
+// it apart from the structs embedding it, to which the method is promoted.
+type GeneratedStruct interface {
+	EvaluableStruct
+	ValidatorGenerated() interface{}
+}
+
 type Validator interface {
 	Struct(s EvaluableStruct) error
 }
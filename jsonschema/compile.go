@@ -0,0 +1,519 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidSchema is returned when a schema document is malformed.
+	ErrInvalidSchema = errors.New("invalid JSON Schema")
+	// ErrUnresolvedRef is returned when a $ref does not point inside the schema document.
+	ErrUnresolvedRef = errors.New("unresolved $ref")
+	// ErrInvalidJSON is returned when the document to validate is not valid JSON.
+	ErrInvalidJSON = errors.New("invalid JSON document")
+)
+
+// defaultBase is the base URI of schemas that do not declare an $id.
+const defaultBase = "mem:///schema.json"
+
+// Compiled is a JSON Schema ready to validate documents. It supports the
+// core, applicator and validation vocabularies of Draft 2020-12, and
+// asserts the format keyword. References must point inside the document;
+// $dynamicRef is resolved like $ref. Patterns are compiled with the RE2
+// syntax of the regexp package rather than ECMA-262, so lookarounds and
+// backreferences are not supported.
+type Compiled struct {
+	root *node
+}
+
+// node is a compiled schema or subschema.
+type node struct {
+	always *bool
+	base   string
+	ref    string
+	target *node
+
+	types []string
+	enum  map[string]bool // canonical forms of the allowed values
+	cnst  string          // canonical form of the constant
+	isCst bool
+
+	multipleOf       *big.Rat
+	minimum          *big.Rat
+	maximum          *big.Rat
+	exclusiveMinimum *big.Rat
+	exclusiveMaximum *big.Rat
+
+	minLength *int
+	maxLength *int
+	pattern   *regexp.Regexp
+	format    string
+
+	minItems    *int
+	maxItems    *int
+	uniqueItems bool
+	minContains *int
+	maxContains *int
+	prefixItems []*node
+	items       *node
+	contains    *node
+
+	minProperties        *int
+	maxProperties        *int
+	required             []string
+	dependentRequired    map[string][]string
+	properties           map[string]*node
+	patternProperties    []patternNode
+	additionalProperties *node
+	propertyNames        *node
+	dependentSchemas     map[string]*node
+
+	allOf []*node
+	anyOf []*node
+	oneOf []*node
+	not   *node
+	ifN   *node
+	thenN *node
+	elseN *node
+}
+
+// patternNode is a compiled patternProperties entry.
+type patternNode struct {
+	re   *regexp.Regexp
+	node *node
+}
+
+// compiler holds the state of a single Compile call.
+type compiler struct {
+	index map[string]*node
+	refs  []*node
+}
+
+// Compile parses and compiles a JSON Schema document.
+func Compile(schema []byte) (*Compiled, error) {
+	raw, err := decode(schema)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSchema, err)
+	}
+	c := &compiler{index: make(map[string]*node)}
+	root, err := c.compile(raw, defaultBase, "")
+	if err != nil {
+		return nil, err
+	}
+	for _, n := range c.refs {
+		target, ok := c.index[n.ref]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnresolvedRef, n.ref)
+		}
+		n.target = target
+	}
+	return &Compiled{root: root}, nil
+}
+
+// decode parses JSON keeping numbers as json.Number.
+func decode(data []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if dec.More() {
+		return nil, errors.New("unexpected data after the top-level value")
+	}
+	return v, nil
+}
+
+// compile compiles raw, located at ptr within the resource identified by base.
+func (c *compiler) compile(raw interface{}, base, ptr string) (*node, error) {
+	n := &node{base: base}
+	if b, ok := raw.(bool); ok {
+		n.always = &b
+		c.index[base+"#"+ptr] = n
+		return n, nil
+	}
+	m, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, c.invalid(ptr, "schema must be an object or a boolean")
+	}
+
+	c.index[base+"#"+ptr] = n
+	if id, ok := m["$id"].(string); ok {
+		resolved, err := resolve(base, id)
+		if err != nil {
+			return nil, c.invalid(ptr, "invalid $id")
+		}
+		n.base, ptr = strings.TrimSuffix(resolved, "#"), ""
+	}
+	c.index[n.base+"#"+ptr] = n
+	for _, key := range []string{"$anchor", "$dynamicAnchor"} {
+		if anchor, ok := m[key].(string); ok {
+			c.index[n.base+"#"+anchor] = n
+		}
+	}
+	for _, key := range []string{"$ref", "$dynamicRef"} {
+		if ref, ok := m[key].(string); ok {
+			resolved, err := resolve(n.base, ref)
+			if err != nil {
+				return nil, c.invalid(ptr, "invalid "+key)
+			}
+			if !strings.Contains(resolved, "#") {
+				resolved += "#"
+			}
+			n.ref = resolved
+			c.refs = append(c.refs, n)
+		}
+	}
+
+	var err error
+	sub := func(key string) (*node, error) {
+		v, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		return c.compile(v, n.base, ptr+"/"+key)
+	}
+	subList := func(key string) ([]*node, error) {
+		v, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		list, ok := v.([]interface{})
+		if !ok {
+			return nil, c.invalid(ptr, key+" must be an array")
+		}
+		nodes := make([]*node, len(list))
+		for i, item := range list {
+			if nodes[i], err = c.compile(item, n.base, ptr+"/"+key+"/"+strconv.Itoa(i)); err != nil {
+				return nil, err
+			}
+		}
+		return nodes, nil
+	}
+	subMap := func(key string) (map[string]*node, error) {
+		v, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, c.invalid(ptr, key+" must be an object")
+		}
+		nodes := make(map[string]*node, len(obj))
+		for name, item := range obj {
+			if nodes[name], err = c.compile(item, n.base, ptr+"/"+key+"/"+escape(name)); err != nil {
+				return nil, err
+			}
+		}
+		return nodes, nil
+	}
+	number := func(key string) (*big.Rat, error) {
+		v, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		r, ok := toRat(v)
+		if !ok {
+			return nil, c.invalid(ptr, key+" must be a number")
+		}
+		return r, nil
+	}
+	count := func(key string) (*int, error) {
+		v, ok := m[key]
+		if !ok {
+			return nil, nil
+		}
+		r, ok := toRat(v)
+		if !ok || !r.IsInt() || r.Sign() < 0 {
+			return nil, c.invalid(ptr, key+" must be a non-negative integer")
+		}
+		i := int(r.Num().Int64())
+		return &i, nil
+	}
+
+	if defs, ok := m["$defs"]; ok {
+		obj, ok := defs.(map[string]interface{})
+		if !ok {
+			return nil, c.invalid(ptr, "$defs must be an object")
+		}
+		for name, def := range obj {
+			if _, err := c.compile(def, n.base, ptr+"/$defs/"+escape(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	switch t := m["type"].(type) {
+	case nil:
+	case string:
+		n.types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			s, ok := item.(string)
+			if !ok {
+				return nil, c.invalid(ptr, "type must be a string or an array of strings")
+			}
+			n.types = append(n.types, s)
+		}
+	default:
+		return nil, c.invalid(ptr, "type must be a string or an array of strings")
+	}
+	if v, ok := m["enum"]; ok {
+		values, ok := v.([]interface{})
+		if !ok {
+			return nil, c.invalid(ptr, "enum must be an array")
+		}
+		n.enum = make(map[string]bool, len(values))
+		for _, value := range values {
+			key, ok := canonical(value)
+			if !ok {
+				return nil, c.invalid(ptr, "enum has a number out of range")
+			}
+			n.enum[key] = true
+		}
+	}
+	if v, ok := m["const"]; ok {
+		if n.cnst, ok = canonical(v); !ok {
+			return nil, c.invalid(ptr, "const has a number out of range")
+		}
+		n.isCst = true
+	}
+
+	if n.multipleOf, err = number("multipleOf"); err != nil {
+		return nil, err
+	}
+	if n.multipleOf != nil && n.multipleOf.Sign() <= 0 {
+		return nil, c.invalid(ptr, "multipleOf must be greater than 0")
+	}
+	if n.minimum, err = number("minimum"); err != nil {
+		return nil, err
+	}
+	if n.maximum, err = number("maximum"); err != nil {
+		return nil, err
+	}
+	if n.exclusiveMinimum, err = number("exclusiveMinimum"); err != nil {
+		return nil, err
+	}
+	if n.exclusiveMaximum, err = number("exclusiveMaximum"); err != nil {
+		return nil, err
+	}
+
+	if n.minLength, err = count("minLength"); err != nil {
+		return nil, err
+	}
+	if n.maxLength, err = count("maxLength"); err != nil {
+		return nil, err
+	}
+	if v, ok := m["pattern"]; ok {
+		s, ok := v.(string)
+		if !ok {
+			return nil, c.invalid(ptr, "pattern must be a string")
+		}
+		if n.pattern, err = regexp.Compile(s); err != nil {
+			return nil, c.invalid(ptr, "invalid pattern: "+err.Error())
+		}
+	}
+	n.format, _ = m["format"].(string)
+
+	if n.minItems, err = count("minItems"); err != nil {
+		return nil, err
+	}
+	if n.maxItems, err = count("maxItems"); err != nil {
+		return nil, err
+	}
+	n.uniqueItems, _ = m["uniqueItems"].(bool)
+	if n.minContains, err = count("minContains"); err != nil {
+		return nil, err
+	}
+	if n.maxContains, err = count("maxContains"); err != nil {
+		return nil, err
+	}
+	if n.prefixItems, err = subList("prefixItems"); err != nil {
+		return nil, err
+	}
+	if n.items, err = sub("items"); err != nil {
+		return nil, err
+	}
+	if n.contains, err = sub("contains"); err != nil {
+		return nil, err
+	}
+
+	if n.minProperties, err = count("minProperties"); err != nil {
+		return nil, err
+	}
+	if n.maxProperties, err = count("maxProperties"); err != nil {
+		return nil, err
+	}
+	if v, ok := m["required"]; ok {
+		if n.required, ok = stringList(v); !ok {
+			return nil, c.invalid(ptr, "required must be an array of strings")
+		}
+	}
+	if v, ok := m["dependentRequired"]; ok {
+		obj, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, c.invalid(ptr, "dependentRequired must be an object")
+		}
+		n.dependentRequired = make(map[string][]string, len(obj))
+		for name, deps := range obj {
+			if n.dependentRequired[name], ok = stringList(deps); !ok {
+				return nil, c.invalid(ptr, "dependentRequired values must be arrays of strings")
+			}
+		}
+	}
+	if n.properties, err = subMap("properties"); err != nil {
+		return nil, err
+	}
+	patterns, err := subMap("patternProperties")
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range sortedKeys(patterns) {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, c.invalid(ptr, "invalid patternProperties pattern: "+err.Error())
+		}
+		n.patternProperties = append(n.patternProperties, patternNode{re: re, node: patterns[p]})
+	}
+	if n.additionalProperties, err = sub("additionalProperties"); err != nil {
+		return nil, err
+	}
+	if n.propertyNames, err = sub("propertyNames"); err != nil {
+		return nil, err
+	}
+	if n.dependentSchemas, err = subMap("dependentSchemas"); err != nil {
+		return nil, err
+	}
+
+	if n.allOf, err = subList("allOf"); err != nil {
+		return nil, err
+	}
+	if n.anyOf, err = subList("anyOf"); err != nil {
+		return nil, err
+	}
+	if n.oneOf, err = subList("oneOf"); err != nil {
+		return nil, err
+	}
+	if n.not, err = sub("not"); err != nil {
+		return nil, err
+	}
+	if n.ifN, err = sub("if"); err != nil {
+		return nil, err
+	}
+	if n.thenN, err = sub("then"); err != nil {
+		return nil, err
+	}
+	if n.elseN, err = sub("else"); err != nil {
+		return nil, err
+	}
+	return n, nil
+}
+
+// invalid returns an ErrInvalidSchema error for the schema at ptr.
+func (c *compiler) invalid(ptr, msg string) error {
+	if ptr == "" {
+		ptr = "#"
+	}
+	return fmt.Errorf("%w at %s: %s", ErrInvalidSchema, ptr, msg)
+}
+
+// resolve resolves ref against base, normalizing the fragment.
+func resolve(base, ref string) (string, error) {
+	b, err := url.Parse(base)
+	if err != nil {
+		return "", err
+	}
+	r, err := url.Parse(ref)
+	if err != nil {
+		return "", err
+	}
+	u := b.ResolveReference(r)
+	frag := u.Fragment
+	u.Fragment = ""
+	if frag == "" && !strings.HasSuffix(ref, "#") {
+		return u.String(), nil
+	}
+	return u.String() + "#" + frag, nil
+}
+
+// escape escapes a JSON Pointer reference token.
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// stringList converts a JSON array of strings.
+func stringList(v interface{}) ([]string, bool) {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil, false
+	}
+	strs := make([]string, len(list))
+	for i, item := range list {
+		if strs[i], ok = item.(string); !ok {
+			return nil, false
+		}
+	}
+	return strs, true
+}
+
+// sortedKeys returns the keys of m in lexical order.
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// maxExponent bounds the decimal exponent of the numbers converted by
+// toRat, whose cost grows with it.
+const maxExponent = 1000
+
+// toRat converts a JSON number to a big.Rat. Numbers whose exponent is
+// beyond maxExponent are not converted; outOfRange reports them.
+func toRat(v interface{}) (*big.Rat, bool) {
+	switch n := v.(type) {
+	case json.Number:
+		s := n.String()
+		if i := strings.IndexAny(s, "eE"); i >= 0 {
+			exp, err := strconv.Atoi(s[i+1:])
+			if err != nil || exp > maxExponent || exp < -maxExponent {
+				return nil, false
+			}
+		}
+		return new(big.Rat).SetString(s)
+	case float64:
+		r := new(big.Rat)
+		if r.SetFloat64(n) == nil {
+			return nil, false
+		}
+		return r, true
+	case float32:
+		return toRat(float64(n))
+	case int:
+		return new(big.Rat).SetInt64(int64(n)), true
+	case int64:
+		return new(big.Rat).SetInt64(n), true
+	}
+	return nil, false
+}
+
+// outOfRange reports whether v is a JSON number that toRat does not
+// convert because of its exponent.
+func outOfRange(v interface{}) bool {
+	if _, ok := v.(json.Number); !ok {
+		return false
+	}
+	_, ok := toRat(v)
+	return !ok
+}
@@ -0,0 +1,448 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solrac97gr/validator"
+)
+
+var (
+	// ErrUnsupportedType is returned for Go types that have no JSON representation.
+	ErrUnsupportedType = errors.New("type cannot be represented in JSON Schema")
+	// ErrInvalidParam is returned when a rule parameter cannot be converted to a keyword.
+	ErrInvalidParam = errors.New("invalid rule parameter")
+)
+
+// formats maps rules to the format keyword they correspond to.
+var formats = map[string]string{
+	"email":             "email",
+	"ipv4":              "ipv4",
+	"ipv6":              "ipv6",
+	"hostname":          "hostname",
+	"fqdn":              "hostname",
+	"idn_hostname":      "idn-hostname",
+	"punycode_hostname": "hostname",
+	"uri":               "uri",
+	"url":               "uri",
+	"http_url":          "uri",
+	"url_host":          "uri",
+	"uri_reference":     "uri-reference",
+}
+
+// patterns maps rules to an equivalent ECMA 262 regular expression.
+var patterns = map[string]string{
+	"alpha":              `^[a-zA-Z]+$`,
+	"alphanum":           `^[a-zA-Z0-9]+$`,
+	"alphaunicode":       `^\p{L}+$`,
+	"alphanumunicode":    `^[\p{L}\p{N}]+$`,
+	"ascii":              `^[\x00-\x7F]+$`,
+	"printascii":         `^[\x20-\x7E]+$`,
+	"boolean":            `^([Tt][Rr][Uu][Ee]|[Ff][Aa][Ll][Ss][Ee])$`,
+	"numeric":            `^[0-9]+$`,
+	"bic":                `^[A-Z]{8}$`,
+	"bcp47_language_tag": `^[a-zA-Z]{1,8}(-[a-zA-Z0-9]{1,8})*$`,
+	"mongodb":            `^[0-9a-fA-F]{24}$`,
+	"datetime":           `^\d{4}-\d{2}-\d{2} \d{2}:\d{2}:\d{2}$`,
+	"e164":               `^\+[1-9]\d{1,14}$`,
+	"eth_addr":           `^(0[xX])?[0-9a-fA-F]{40}$`,
+	"mac":                macPattern(6, 8, 20),
+	"mac48":              macPattern(6),
+	"eui64":              macPattern(8),
+}
+
+// macPattern returns a pattern matching MAC addresses of the given lengths
+// in bytes, in the formats accepted by validations.ParseMAC.
+func macPattern(lengths ...int) string {
+	var alts []string
+	for _, sep := range []string{":", "-"} {
+		for _, n := range lengths {
+			alts = append(alts, fmt.Sprintf("[0-9A-Fa-f]{2}(%s[0-9A-Fa-f]{2}){%d}", sep, n-1))
+		}
+	}
+	for _, n := range lengths {
+		alts = append(alts, fmt.Sprintf(`[0-9A-Fa-f]{4}(\.[0-9A-Fa-f]{4}){%d}`, n/2-1))
+	}
+	return "^(" + strings.Join(alts, "|") + ")$"
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// Generator builds JSON Schemas from Go types. Named struct types are
+// emitted once as definitions and referenced with $ref.
+type Generator struct {
+	// RefPrefix is prepended to definition names to build $ref values.
+	// It defaults to "#/$defs/".
+	RefPrefix string
+
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+	refs  map[string]int
+}
+
+// Generate returns the schema of the type of v, with the definitions of
+// every nested named struct under $defs.
+func Generate(v interface{}) (*Schema, error) {
+	t := reflect.TypeOf(v)
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil, ErrUnsupportedType
+	}
+
+	g := &Generator{}
+	s, err := g.Schema(t)
+	if err != nil {
+		return nil, err
+	}
+
+	root := s
+	if s.Ref != "" {
+		name := g.names[t]
+		def := *g.defs[name]
+		root = &def
+		if g.refs[name] == 1 {
+			delete(g.defs, name)
+		}
+		root.Title = name
+	}
+	root.Schema = Draft
+	if len(g.defs) > 0 {
+		root.Defs = g.defs
+	}
+	return root, nil
+}
+
+// Defs returns the definitions collected so far, keyed by name.
+func (g *Generator) Defs() map[string]*Schema {
+	return g.defs
+}
+
+// Schema returns the schema of t. Named struct types are added to the
+// definitions and a $ref to them is returned.
+func (g *Generator) Schema(t reflect.Type) (*Schema, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}, nil
+	case reflect.String:
+		return &Schema{Type: "string"}, nil
+	case reflect.Interface:
+		return &Schema{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", ContentEncoding: "base64"}, nil
+		}
+		items, err := g.Schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "array", Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+		}
+		values, err := g.Schema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return &Schema{Type: "object", AdditionalProperties: values}, nil
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}, nil
+		}
+		if t.Name() == "" {
+			return g.object(t)
+		}
+		return g.ref(t)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+}
+
+// ref adds the named struct type t to the definitions and returns a
+// reference to it.
+func (g *Generator) ref(t reflect.Type) (*Schema, error) {
+	if g.defs == nil {
+		g.defs = make(map[string]*Schema)
+		g.names = make(map[reflect.Type]string)
+		g.refs = make(map[string]int)
+	}
+	prefix := g.RefPrefix
+	if prefix == "" {
+		prefix = "#/$defs/"
+	}
+
+	name, ok := g.names[t]
+	if !ok {
+		name = g.defName(t)
+		g.names[t] = name
+		// Register a placeholder first so recursive types terminate.
+		g.defs[name] = &Schema{}
+		s, err := g.object(t)
+		if err != nil {
+			return nil, err
+		}
+		*g.defs[name] = *s
+	}
+	g.refs[name]++
+	return &Schema{Ref: prefix + name}, nil
+}
+
+// defName returns a definition name for t that is unique within g.
+func (g *Generator) defName(t reflect.Type) string {
+	name := t.Name()
+	if _, taken := g.defs[name]; !taken {
+		return name
+	}
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	name = pkg + "." + t.Name()
+	for i := 2; ; i++ {
+		if _, taken := g.defs[name]; !taken {
+			return name
+		}
+		name = pkg + "." + t.Name() + strconv.Itoa(i)
+	}
+}
+
+// object returns the schema of struct type t.
+func (g *Generator) object(t reflect.Type) (*Schema, error) {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	if err := g.properties(t, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// properties adds the fields of struct type t to s. Embedded structs
+// without a JSON name are flattened, as encoding/json does.
+func (g *Generator) properties(t reflect.Type, s *Schema) error {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		name, skip := jsonName(sf)
+		if skip {
+			continue
+		}
+		ft := sf.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if sf.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			if err := g.properties(ft, s); err != nil {
+				return err
+			}
+			continue
+		}
+		if !sf.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = sf.Name
+		}
+
+		prop, err := g.Schema(sf.Type)
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), sf.Name, err)
+		}
+		required, err := applyRules(prop, ft, validator.ParseTag(sf.Tag.Get(validator.TagName)))
+		if err != nil {
+			return fmt.Errorf("%s.%s: %w", t.Name(), sf.Name, err)
+		}
+		if required {
+			s.Required = append(s.Required, name)
+		}
+		s.Properties[name] = prop
+	}
+	return nil
+}
+
+// jsonName returns the name of a field in its JSON encoding, and whether
+// the field is omitted from it.
+func jsonName(sf reflect.StructField) (string, bool) {
+	tag := sf.Tag.Get("json")
+	if tag == "-" {
+		return "", true
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name, false
+}
+
+// applyRules translates validation rules into keywords of s, a schema for
+// a value of type t. It reports whether the field is required. Rules that
+// have no JSON Schema equivalent are skipped.
+func applyRules(s *Schema, t reflect.Type, rules []validator.TagRule) (bool, error) {
+	required := false
+	for _, r := range rules {
+		if r.Name == "required" {
+			required = true
+			continue
+		}
+		if err := applyRule(s, t, r); err != nil {
+			return false, fmt.Errorf("%s: %w", r.Name, err)
+		}
+	}
+	return required, nil
+}
+
+// applyRule translates a single rule into keywords of s.
+func applyRule(s *Schema, t reflect.Type, r validator.TagRule) error {
+	if format, ok := formats[r.Name]; ok {
+		s.Format = format
+		return nil
+	}
+	if pattern, ok := patterns[r.Name]; ok {
+		addPattern(s, pattern)
+		return nil
+	}
+
+	switch r.Name {
+	case "ip":
+		// Draft 2020-12 has no format for either family.
+		s.AnyOf = []*Schema{{Format: "ipv4"}, {Format: "ipv6"}}
+	case "contains":
+		addPattern(s, regexp.QuoteMeta(r.Param))
+	case "startswith":
+		addPattern(s, "^"+regexp.QuoteMeta(r.Param))
+	case "endswith":
+		addPattern(s, regexp.QuoteMeta(r.Param)+"$")
+	case "base64":
+		s.ContentEncoding = "base64"
+	case "base64url", "base64rawurl":
+		s.ContentEncoding = "base64url"
+	case "unique":
+		s.UniqueItems = true
+	case "even":
+		two := 2.0
+		s.MultipleOf = &two
+	case "positive":
+		zero := 0.0
+		s.ExclusiveMinimum = &zero
+	case "negative":
+		zero := 0.0
+		s.ExclusiveMaximum = &zero
+	case "multipleof":
+		n, err := number(r.Param)
+		if err != nil {
+			return err
+		}
+		s.MultipleOf = &n
+	case "eq":
+		n, err := number(r.Param)
+		if err != nil {
+			return err
+		}
+		s.Const = n
+	case "oneof":
+		for _, v := range strings.Fields(r.Param) {
+			if t.Kind() == reflect.String {
+				s.Enum = append(s.Enum, v)
+				continue
+			}
+			n, err := number(v)
+			if err != nil {
+				return err
+			}
+			s.Enum = append(s.Enum, n)
+		}
+	case "min", "gte", "gt", "max", "lte", "lt", "len":
+		return applyBound(s, t, r)
+	}
+	return nil
+}
+
+// applyBound translates a size based rule into the bound keyword matching
+// the kind of t.
+func applyBound(s *Schema, t reflect.Type, r validator.TagRule) error {
+	n, err := number(r.Param)
+	if err != nil {
+		return err
+	}
+
+	switch t.Kind() {
+	case reflect.String, reflect.Slice, reflect.Array, reflect.Map:
+		size := int(n)
+		var lower, upper **int
+		switch {
+		case t.Kind() == reflect.String || isBytes(t):
+			lower, upper = &s.MinLength, &s.MaxLength
+		case t.Kind() == reflect.Map:
+			lower, upper = &s.MinProperties, &s.MaxProperties
+		default:
+			lower, upper = &s.MinItems, &s.MaxItems
+		}
+		switch r.Name {
+		case "min", "gte":
+			*lower = &size
+		case "gt":
+			size++
+			*lower = &size
+		case "max", "lte":
+			*upper = &size
+		case "lt":
+			size--
+			*upper = &size
+		case "len":
+			*lower, *upper = &size, &size
+		}
+	default:
+		switch r.Name {
+		case "min", "gte":
+			s.Minimum = &n
+		case "gt":
+			s.ExclusiveMinimum = &n
+		case "max", "lte":
+			s.Maximum = &n
+		case "lt":
+			s.ExclusiveMaximum = &n
+		case "len":
+			s.Const = n
+		}
+	}
+	return nil
+}
+
+// addPattern sets the pattern of s, moving additional patterns to allOf.
+func addPattern(s *Schema, pattern string) {
+	if s.Pattern == "" {
+		s.Pattern = pattern
+		return
+	}
+	s.AllOf = append(s.AllOf, &Schema{Pattern: pattern})
+}
+
+// number parses a numeric rule parameter.
+func number(param string) (float64, error) {
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%w %q", ErrInvalidParam, param)
+	}
+	return n, nil
+}
+
+// isBytes reports whether t is a byte slice, which encoding/json encodes
+// as a base64 string.
+func isBytes(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice && t.Elem().Kind() == reflect.Uint8
+}
+
+// MarshalIndent returns the indented JSON encoding of s.
+func (s *Schema) MarshalIndent() ([]byte, error) {
+	return json.MarshalIndent(s, "", "  ")
+}
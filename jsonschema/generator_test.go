@@ -0,0 +1,224 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+type address struct {
+	Street string `json:"street" validate:"required,max=100"`
+	Zip    string `json:"zip,omitempty" validate:"numeric,len=5"`
+}
+
+type contact struct {
+	Name    string            `json:"name" validate:"required,min=2"`
+	Age     int               `json:"age" validate:"gte=18,lt=130"`
+	Email   string            `json:"email" validate:"email"`
+	Role    string            `json:"role" validate:"oneof=admin user"`
+	Tags    []string          `json:"tags" validate:"unique,min=1"`
+	Labels  map[string]string `json:"labels" validate:"max=3"`
+	Home    *address          `json:"home"`
+	Work    address           `json:"work"`
+	Created time.Time         `json:"created"`
+	Avatar  []byte            `json:"avatar"`
+	Secret  string            `json:"-"`
+	private string
+}
+
+type tree struct {
+	Value    int     `json:"value"`
+	Next     *tree   `json:"next"`
+	Children []*tree `json:"children"`
+}
+
+type embedded struct {
+	address
+	Extra string `json:"extra" validate:"startswith=x,endswith=y"`
+}
+
+type host struct {
+	IP    string  `json:"ip" validate:"ip"`
+	MAC   string  `json:"mac" validate:"mac48"`
+	Ratio float64 `json:"ratio" validate:"positive,multipleof=0.5"`
+	Count uint    `json:"count" validate:"even,oneof=2 4"`
+}
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{"object", address{}, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"title": "address",
+			"type": "object",
+			"properties": {
+				"street": {"type": "string", "maxLength": 100},
+				"zip": {"type": "string", "pattern": "^[0-9]+$", "minLength": 5, "maxLength": 5}
+			},
+			"required": ["street"]
+		}`},
+		{"definitions", &contact{}, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"$defs": {
+				"address": {
+					"type": "object",
+					"properties": {
+						"street": {"type": "string", "maxLength": 100},
+						"zip": {"type": "string", "pattern": "^[0-9]+$", "minLength": 5, "maxLength": 5}
+					},
+					"required": ["street"]
+				}
+			},
+			"title": "contact",
+			"type": "object",
+			"properties": {
+				"age": {"type": "integer", "minimum": 18, "exclusiveMaximum": 130},
+				"avatar": {"type": "string", "contentEncoding": "base64"},
+				"created": {"type": "string", "format": "date-time"},
+				"email": {"type": "string", "format": "email"},
+				"home": {"$ref": "#/$defs/address"},
+				"labels": {"type": "object", "additionalProperties": {"type": "string"}, "maxProperties": 3},
+				"name": {"type": "string", "minLength": 2},
+				"role": {"type": "string", "enum": ["admin", "user"]},
+				"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "uniqueItems": true},
+				"work": {"$ref": "#/$defs/address"}
+			},
+			"required": ["name"]
+		}`},
+		// Recursive types refer to their own definition.
+		{"pointer cycle", tree{}, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"$defs": {
+				"tree": {
+					"type": "object",
+					"properties": {
+						"children": {"type": "array", "items": {"$ref": "#/$defs/tree"}},
+						"next": {"$ref": "#/$defs/tree"},
+						"value": {"type": "integer"}
+					}
+				}
+			},
+			"title": "tree",
+			"type": "object",
+			"properties": {
+				"children": {"type": "array", "items": {"$ref": "#/$defs/tree"}},
+				"next": {"$ref": "#/$defs/tree"},
+				"value": {"type": "integer"}
+			}
+		}`},
+		{"embedded", embedded{}, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"title": "embedded",
+			"type": "object",
+			"properties": {
+				"extra": {"type": "string", "pattern": "^x", "allOf": [{"pattern": "y$"}]},
+				"street": {"type": "string", "maxLength": 100},
+				"zip": {"type": "string", "pattern": "^[0-9]+$", "minLength": 5, "maxLength": 5}
+			},
+			"required": ["street"]
+		}`},
+		{"keywords", host{}, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"title": "host",
+			"type": "object",
+			"properties": {
+				"count": {"type": "integer", "enum": [2, 4], "multipleOf": 2},
+				"ip": {"type": "string", "anyOf": [{"format": "ipv4"}, {"format": "ipv6"}]},
+				"mac": {"type": "string", "pattern": "^([0-9A-Fa-f]{2}(:[0-9A-Fa-f]{2}){5}|[0-9A-Fa-f]{2}(-[0-9A-Fa-f]{2}){5}|[0-9A-Fa-f]{4}(\\.[0-9A-Fa-f]{4}){2})$"},
+				"ratio": {"type": "number", "exclusiveMinimum": 0, "multipleOf": 0.5}
+			}
+		}`},
+		{"map", map[string]int{}, `{
+			"$schema": "https://json-schema.org/draft/2020-12/schema",
+			"type": "object",
+			"additionalProperties": {"type": "integer"}
+		}`},
+	}
+	for _, tt := range tests {
+		s, err := Generate(tt.value)
+		if err != nil {
+			t.Errorf("%s: Generate() = %v", tt.name, err)
+			continue
+		}
+		b, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := compact(t, string(b)), compact(t, tt.want); got != want {
+			t.Errorf("%s: Generate() =\n%s\nwant\n%s", tt.name, got, want)
+		}
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  error
+	}{
+		{"nil", nil, ErrUnsupportedType},
+		{"channel", make(chan int), ErrUnsupportedType},
+		{"integer keys", map[int]string{}, ErrUnsupportedType},
+		{"function field", struct{ F func() }{}, ErrUnsupportedType},
+		{"bad bound", struct {
+			N int `validate:"min=abc"`
+		}{}, ErrInvalidParam},
+		{"bad enum", struct {
+			N int `validate:"oneof=1 two"`
+		}{}, ErrInvalidParam},
+	}
+	for _, tt := range tests {
+		if _, err := Generate(tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Generate() = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// TestGenerateCompiles checks that generated schemas accept and reject the
+// same documents as the rules they come from.
+func TestGenerateCompiles(t *testing.T) {
+	tests := []struct {
+		value interface{}
+		doc   string
+		rules []string
+	}{
+		{host{}, `{"ip": "10.0.0.1", "mac": "00:1a:2b:3c:4d:5e", "ratio": 1.5, "count": 4}`, nil},
+		{host{}, `{"ip": "2001:db8::1", "mac": "001a.2b3c.4d5e"}`, nil},
+		{host{}, `{"ip": "10.0.0.256"}`, []string{"anyOf"}},
+		{host{}, `{"mac": "00:1a-2b:3c:4d:5e"}`, []string{"pattern"}},
+		{host{}, `{"ratio": 0.25, "count": 6}`, []string{"enum", "multipleOf"}},
+		{tree{}, `{"value": 1, "next": {"value": 2, "children": [{"value": 3}]}}`, nil},
+		{tree{}, `{"next": {"children": [{"value": "3"}]}}`, []string{"type"}},
+		{address{}, `{"zip": "1234"}`, []string{"required", "minLength"}},
+	}
+	for _, tt := range tests {
+		s, err := Generate(tt.value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		schema, err := json.Marshal(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		runSchemaTests(t, []schemaTest{{string(schema), tt.doc, tt.rules}})
+	}
+}
+
+// compact returns the JSON document s without spaces and with its object
+// keys sorted.
+func compact(t *testing.T, s string) string {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("invalid JSON %s: %v", s, err)
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
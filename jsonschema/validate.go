@@ -0,0 +1,442 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/solrac97gr/validator"
+	"github.com/solrac97gr/validator/validations"
+)
+
+var (
+	// ErrType is returned when a value does not have the expected JSON type.
+	ErrType = errors.New("value must be of type")
+	// ErrPattern is returned when a string does not match the pattern.
+	ErrPattern = errors.New("value must match the pattern")
+	// ErrContains is returned when an array has too few or too many items matching contains.
+	ErrContains = errors.New("array has the wrong number of matching items")
+	// ErrAdditionalProperty is returned when a property is not allowed by the schema.
+	ErrAdditionalProperty = errors.New("property is not allowed")
+	// ErrAnyOf is returned when a value is valid against none of the anyOf schemas.
+	ErrAnyOf = errors.New("value must match at least one schema")
+	// ErrOneOf is returned when a value is not valid against exactly one of the oneOf schemas.
+	ErrOneOf = errors.New("value must match exactly one schema")
+	// ErrNot is returned when a value is valid against the not schema.
+	ErrNot = errors.New("value must not match the schema")
+	// ErrFalseSchema is returned when a value is checked against the false schema.
+	ErrFalseSchema = errors.New("no value is allowed")
+	// ErrNumberRange is returned when a number has an exponent too large to be compared.
+	ErrNumberRange = errors.New("number is out of range")
+	// ErrRefDepth is returned when a value is checked against too many nested $ref.
+	ErrRefDepth = errors.New("$ref recursion is too deep")
+)
+
+// maxRefDepth bounds the number of $ref followed without descending into
+// the value, so that schemas such as {"$ref": "#"} do not loop forever.
+const maxRefDepth = 32
+
+// formatChecks maps the format keyword to the function asserting it.
+var formatChecks = map[string]func(string) error{
+	"email":         validations.IsValidEmail,
+	"ipv4":          validations.ValidateIPv4Address,
+	"ipv6":          validations.ValidateIPv6Address,
+	"hostname":      validations.ValidateHostname,
+	"idn-hostname":  validations.ValidateUnicodeHostname,
+	"uri":           validations.ValidateURL,
+	"uri-reference": validations.ValidateURIReference,
+	"date-time":     validations.IsValidRFC3339Datetime,
+}
+
+// Validate parses the JSON document doc and validates it against the
+// schema. Failures are returned as validator.ValidationErrors whose Field
+// is the JSON Pointer of the offending value.
+func (c *Compiled) Validate(doc []byte) error {
+	v, err := decode(doc)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidJSON, err)
+	}
+	return c.ValidateValue(v)
+}
+
+// ValidateValue validates a value decoded from JSON, such as the result of
+// json.Unmarshal into an interface{}.
+func (c *Compiled) ValidateValue(v interface{}) error {
+	var errs validator.ValidationErrors
+	c.root.validate(v, "", 0, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// valid reports whether v is valid against n without collecting errors.
+func (n *node) valid(v interface{}, ptr string, refs int) bool {
+	var errs validator.ValidationErrors
+	n.validate(v, ptr, refs, &errs)
+	return len(errs) == 0
+}
+
+// validate checks v, located at ptr in the document, against n. refs
+// counts the $ref followed since the last descent into a child of v.
+func (n *node) validate(v interface{}, ptr string, refs int, errs *validator.ValidationErrors) {
+	fail := func(keyword string, param string, err error) {
+		*errs = append(*errs, &validator.FieldError{Field: ptr, Rule: keyword, Param: param, Err: err})
+	}
+
+	if n.always != nil {
+		if !*n.always {
+			fail("false", "", ErrFalseSchema)
+		}
+		return
+	}
+	// Numbers whose exponent is out of range are only reported by the
+	// keywords that need their value.
+	num, isNum := toRat(v)
+	huge := outOfRange(v)
+	rangeErr := func(keyword string) {
+		fail(keyword, "", fmt.Errorf("%w: %s", ErrNumberRange, v))
+	}
+	if n.target != nil {
+		if refs >= maxRefDepth {
+			fail("$ref", n.ref, ErrRefDepth)
+			return
+		}
+		n.target.validate(v, ptr, refs+1, errs)
+	}
+
+	if len(n.types) > 0 && !hasType(v, num, n.types) {
+		if huge && slices.Contains(n.types, "integer") {
+			rangeErr("type")
+		} else {
+			fail("type", fmt.Sprint(n.types), fmt.Errorf("%w %v", ErrType, n.types))
+		}
+	}
+	if n.enum != nil || n.isCst {
+		key, ok := canonical(v)
+		switch {
+		case !ok && n.enum != nil:
+			rangeErr("enum")
+		case !ok:
+			rangeErr("const")
+		default:
+			if n.enum != nil && !n.enum[key] {
+				fail("enum", "", fmt.Errorf("%w the allowed values", validator.ErrOneOf))
+			}
+			if n.isCst && key != n.cnst {
+				fail("const", "", fmt.Errorf("%w the constant", validator.ErrEq))
+			}
+		}
+	}
+
+	switch val := v.(type) {
+	case string:
+		n.validateString(val, fail)
+	case []interface{}:
+		n.validateArray(val, ptr, errs, fail)
+	case map[string]interface{}:
+		n.validateObject(val, ptr, refs, errs, fail)
+	default:
+		if isNum {
+			n.validateNumber(num, fail)
+		} else if keyword := n.numberKeyword(); huge && keyword != "" {
+			rangeErr(keyword)
+		}
+	}
+
+	for _, sub := range n.allOf {
+		sub.validate(v, ptr, refs, errs)
+	}
+	if n.anyOf != nil {
+		matched := false
+		for _, sub := range n.anyOf {
+			if sub.valid(v, ptr, refs) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			fail("anyOf", "", ErrAnyOf)
+		}
+	}
+	if n.oneOf != nil {
+		matched := 0
+		for _, sub := range n.oneOf {
+			if sub.valid(v, ptr, refs) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			fail("oneOf", "", ErrOneOf)
+		}
+	}
+	if n.not != nil && n.not.valid(v, ptr, refs) {
+		fail("not", "", ErrNot)
+	}
+	if n.ifN != nil {
+		if n.ifN.valid(v, ptr, refs) {
+			if n.thenN != nil {
+				n.thenN.validate(v, ptr, refs, errs)
+			}
+		} else if n.elseN != nil {
+			n.elseN.validate(v, ptr, refs, errs)
+		}
+	}
+}
+
+// validateNumber applies the numeric keywords.
+func (n *node) validateNumber(r *big.Rat, fail func(string, string, error)) {
+	if n.multipleOf != nil && !new(big.Rat).Quo(r, n.multipleOf).IsInt() {
+		fail("multipleOf", n.multipleOf.RatString(), fmt.Errorf("%w %s", validator.ErrNotMultipleOf, n.multipleOf.RatString()))
+	}
+	if n.minimum != nil && r.Cmp(n.minimum) < 0 {
+		fail("minimum", n.minimum.RatString(), fmt.Errorf("%w %s", validator.ErrMin, n.minimum.RatString()))
+	}
+	if n.maximum != nil && r.Cmp(n.maximum) > 0 {
+		fail("maximum", n.maximum.RatString(), fmt.Errorf("%w %s", validator.ErrMax, n.maximum.RatString()))
+	}
+	if n.exclusiveMinimum != nil && r.Cmp(n.exclusiveMinimum) <= 0 {
+		fail("exclusiveMinimum", n.exclusiveMinimum.RatString(), fmt.Errorf("%w %s", validator.ErrGt, n.exclusiveMinimum.RatString()))
+	}
+	if n.exclusiveMaximum != nil && r.Cmp(n.exclusiveMaximum) >= 0 {
+		fail("exclusiveMaximum", n.exclusiveMaximum.RatString(), fmt.Errorf("%w %s", validator.ErrLt, n.exclusiveMaximum.RatString()))
+	}
+}
+
+// numberKeyword returns the first numeric keyword of n, or "" if it has
+// none.
+func (n *node) numberKeyword() string {
+	switch {
+	case n.multipleOf != nil:
+		return "multipleOf"
+	case n.minimum != nil:
+		return "minimum"
+	case n.maximum != nil:
+		return "maximum"
+	case n.exclusiveMinimum != nil:
+		return "exclusiveMinimum"
+	case n.exclusiveMaximum != nil:
+		return "exclusiveMaximum"
+	}
+	return ""
+}
+
+// validateString applies the string keywords.
+func (n *node) validateString(s string, fail func(string, string, error)) {
+	length := utf8.RuneCountInString(s)
+	if n.minLength != nil && length < *n.minLength {
+		fail("minLength", strconv.Itoa(*n.minLength), fmt.Errorf("%w %d", validator.ErrMin, *n.minLength))
+	}
+	if n.maxLength != nil && length > *n.maxLength {
+		fail("maxLength", strconv.Itoa(*n.maxLength), fmt.Errorf("%w %d", validator.ErrMax, *n.maxLength))
+	}
+	if n.pattern != nil && !n.pattern.MatchString(s) {
+		fail("pattern", n.pattern.String(), fmt.Errorf("%w %s", ErrPattern, n.pattern))
+	}
+	if check, ok := formatChecks[n.format]; ok {
+		if err := check(s); err != nil {
+			fail("format", n.format, err)
+		}
+	}
+}
+
+// validateArray applies the array keywords.
+func (n *node) validateArray(arr []interface{}, ptr string, errs *validator.ValidationErrors, fail func(string, string, error)) {
+	if n.minItems != nil && len(arr) < *n.minItems {
+		fail("minItems", strconv.Itoa(*n.minItems), fmt.Errorf("%w %d", validator.ErrMin, *n.minItems))
+	}
+	if n.maxItems != nil && len(arr) > *n.maxItems {
+		fail("maxItems", strconv.Itoa(*n.maxItems), fmt.Errorf("%w %d", validator.ErrMax, *n.maxItems))
+	}
+	if n.uniqueItems {
+		seen := make(map[string]bool, len(arr))
+		for _, item := range arr {
+			key, ok := canonical(item)
+			if !ok {
+				fail("uniqueItems", "", fmt.Errorf("%w: cannot compare the items", ErrNumberRange))
+				break
+			}
+			if seen[key] {
+				fail("uniqueItems", "", validator.ErrNotUnique)
+				break
+			}
+			seen[key] = true
+		}
+	}
+
+	for i, item := range arr {
+		itemPtr := ptr + "/" + strconv.Itoa(i)
+		switch {
+		case i < len(n.prefixItems):
+			n.prefixItems[i].validate(item, itemPtr, 0, errs)
+		case n.items != nil:
+			n.items.validate(item, itemPtr, 0, errs)
+		}
+	}
+
+	if n.contains != nil {
+		matched := 0
+		for i, item := range arr {
+			if n.contains.valid(item, ptr+"/"+strconv.Itoa(i), 0) {
+				matched++
+			}
+		}
+		min := 1
+		if n.minContains != nil {
+			min = *n.minContains
+		}
+		if matched < min || (n.maxContains != nil && matched > *n.maxContains) {
+			fail("contains", "", ErrContains)
+		}
+	}
+}
+
+// validateObject applies the object keywords.
+func (n *node) validateObject(obj map[string]interface{}, ptr string, refs int, errs *validator.ValidationErrors, fail func(string, string, error)) {
+	if n.minProperties != nil && len(obj) < *n.minProperties {
+		fail("minProperties", strconv.Itoa(*n.minProperties), fmt.Errorf("%w %d", validator.ErrMin, *n.minProperties))
+	}
+	if n.maxProperties != nil && len(obj) > *n.maxProperties {
+		fail("maxProperties", strconv.Itoa(*n.maxProperties), fmt.Errorf("%w %d", validator.ErrMax, *n.maxProperties))
+	}
+	for _, name := range n.required {
+		if _, ok := obj[name]; !ok {
+			*errs = append(*errs, &validator.FieldError{Field: ptr + "/" + escape(name), Rule: "required", Err: validator.ErrRequired})
+		}
+	}
+	for _, name := range sortedKeys(n.dependentRequired) {
+		if _, ok := obj[name]; !ok {
+			continue
+		}
+		for _, dep := range n.dependentRequired[name] {
+			if _, ok := obj[dep]; !ok {
+				*errs = append(*errs, &validator.FieldError{Field: ptr + "/" + escape(dep), Rule: "dependentRequired", Param: name, Err: validator.ErrRequired})
+			}
+		}
+	}
+	for _, name := range sortedKeys(n.dependentSchemas) {
+		if _, ok := obj[name]; ok {
+			n.dependentSchemas[name].validate(obj, ptr, refs, errs)
+		}
+	}
+
+	for _, name := range sortedKeys(obj) {
+		value := obj[name]
+		propPtr := ptr + "/" + escape(name)
+		if n.propertyNames != nil {
+			n.propertyNames.validate(name, propPtr, 0, errs)
+		}
+
+		evaluated := false
+		if prop, ok := n.properties[name]; ok {
+			prop.validate(value, propPtr, 0, errs)
+			evaluated = true
+		}
+		for _, pp := range n.patternProperties {
+			if pp.re.MatchString(name) {
+				pp.node.validate(value, propPtr, 0, errs)
+				evaluated = true
+			}
+		}
+		if !evaluated && n.additionalProperties != nil {
+			if n.additionalProperties.always != nil && !*n.additionalProperties.always {
+				*errs = append(*errs, &validator.FieldError{Field: propPtr, Rule: "additionalProperties", Err: ErrAdditionalProperty})
+				continue
+			}
+			n.additionalProperties.validate(value, propPtr, 0, errs)
+		}
+	}
+}
+
+// hasType reports whether v is of one of the given JSON types. num is the
+// value of v if it is a number, or nil.
+func hasType(v interface{}, num *big.Rat, types []string) bool {
+	for _, t := range types {
+		switch t {
+		case "null":
+			if v == nil {
+				return true
+			}
+		case "boolean":
+			if _, ok := v.(bool); ok {
+				return true
+			}
+		case "string":
+			if _, ok := v.(string); ok {
+				return true
+			}
+		case "array":
+			if _, ok := v.([]interface{}); ok {
+				return true
+			}
+		case "object":
+			if _, ok := v.(map[string]interface{}); ok {
+				return true
+			}
+		case "number":
+			if num != nil || outOfRange(v) {
+				return true
+			}
+		case "integer":
+			if num != nil && num.IsInt() {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// canonical returns a string identifying the JSON value v: equal values
+// have the same canonical form. Numbers are compared by value, so 1 and
+// 1.0 are equal, and object keys are sorted. It reports false when v holds
+// a number out of range, which cannot be compared by value.
+func canonical(v interface{}) (string, bool) {
+	var b strings.Builder
+	ok := writeCanonical(&b, v)
+	return b.String(), ok
+}
+
+// writeCanonical writes the canonical form of v to b, reporting false when
+// v holds a number out of range.
+func writeCanonical(b *strings.Builder, v interface{}) bool {
+	if r, ok := toRat(v); ok {
+		b.WriteString(r.RatString())
+		return true
+	}
+	ok := true
+	switch val := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(val))
+	case string:
+		b.WriteString(strconv.Quote(val))
+	case []interface{}:
+		b.WriteByte('[')
+		for i, item := range val {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			ok = writeCanonical(b, item) && ok
+		}
+		b.WriteByte(']')
+	case map[string]interface{}:
+		b.WriteByte('{')
+		for i, key := range sortedKeys(val) {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			b.WriteString(strconv.Quote(key))
+			b.WriteByte(':')
+			ok = writeCanonical(b, val[key]) && ok
+		}
+		b.WriteByte('}')
+	default:
+		return false
+	}
+	return ok
+}
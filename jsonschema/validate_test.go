@@ -0,0 +1,208 @@
+package jsonschema
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/solrac97gr/validator"
+)
+
+// schemaTest is a document validated against a schema, failing with the
+// given rules in order, or passing when there are none.
+type schemaTest struct {
+	schema string
+	doc    string
+	rules  []string
+}
+
+func runSchemaTests(t *testing.T, tests []schemaTest) {
+	t.Helper()
+	for _, tt := range tests {
+		c, err := Compile([]byte(tt.schema))
+		if err != nil {
+			t.Errorf("Compile(%s) = %v", tt.schema, err)
+			continue
+		}
+		var rules []string
+		err = c.Validate([]byte(tt.doc))
+		var errs validator.ValidationErrors
+		if err != nil && !errors.As(err, &errs) {
+			t.Errorf("%s against %s: %v, want ValidationErrors", tt.doc, tt.schema, err)
+			continue
+		}
+		for _, fe := range errs {
+			rules = append(rules, fe.Rule)
+		}
+		if !equalStrings(rules, tt.rules) {
+			t.Errorf("%s against %s: failed %q, want %q (%v)", tt.doc, tt.schema, rules, tt.rules, err)
+		}
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNumberRange(t *testing.T) {
+	runSchemaTests(t, []schemaTest{
+		// Numbers out of range are valid when no keyword needs their value.
+		{`{}`, `1e1001`, nil},
+		{`true`, `-1e-1001`, nil},
+		{`{"type": "number"}`, `1e1001`, nil},
+		{`{"type": ["string", "number"]}`, `1e-1001`, nil},
+		{`{"type": "string"}`, `1e1001`, []string{"type"}},
+		{`{"not": {"type": "string"}}`, `1e1001`, nil},
+		{`{"not": {"type": "number"}}`, `1e1001`, []string{"not"}},
+		{`{"items": {"type": "number"}, "minItems": 2}`, `[1e1001, 1e-1001]`, nil},
+		{`{"properties": {"a": {"maxLength": 2}}}`, `{"a": 1E+5000}`, nil},
+		{`{"anyOf": [{"type": "string"}, {"type": "number"}]}`, `1e1001`, nil},
+
+		// Keywords that need the value report it.
+		{`{"minimum": 0}`, `1e1001`, []string{"minimum"}},
+		{`{"exclusiveMaximum": 1}`, `1e-1001`, []string{"exclusiveMaximum"}},
+		{`{"multipleOf": 2}`, `1e1001`, []string{"multipleOf"}},
+		{`{"type": "integer"}`, `1e1001`, []string{"type"}},
+		{`{"enum": [1, 2]}`, `1e1001`, []string{"enum"}},
+		{`{"const": 1}`, `1e-1001`, []string{"const"}},
+		{`{"uniqueItems": true}`, `[1, [1e1001]]`, []string{"uniqueItems"}},
+
+		// Numbers in range are compared by value.
+		{`{"maximum": 1e1000}`, `1e1000`, nil},
+		{`{"exclusiveMaximum": 1e1000}`, `1e1000`, []string{"exclusiveMaximum"}},
+		{`{"type": "integer"}`, `1e3`, nil},
+		{`{"const": 1000}`, `1e3`, nil},
+		{`{"uniqueItems": true}`, `[1e3, 1000.0]`, []string{"uniqueItems"}},
+	})
+}
+
+func TestNumberRangeInSchema(t *testing.T) {
+	for _, schema := range []string{`{"enum": [1e1001]}`, `{"const": [1e-1001]}`, `{"minimum": 1e1001}`} {
+		if _, err := Compile([]byte(schema)); !errors.Is(err, ErrInvalidSchema) {
+			t.Errorf("Compile(%s) = %v, want %v", schema, err, ErrInvalidSchema)
+		}
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	runSchemaTests(t, []schemaTest{
+		{`{"type": "integer"}`, `1.0`, nil},
+		{`{"type": "integer"}`, `1.5`, []string{"type"}},
+		{`{"type": ["null", "boolean"]}`, `null`, nil},
+		{`{"type": "object"}`, `[]`, []string{"type"}},
+		{`false`, `null`, []string{"false"}},
+		{`{"minimum": 1, "exclusiveMaximum": 3}`, `3`, []string{"exclusiveMaximum"}},
+		{`{"multipleOf": 0.1}`, `0.3`, nil},
+		{`{"minLength": 2, "maxLength": 3}`, `"日本語"`, nil},
+		{`{"minLength": 2}`, `"日"`, []string{"minLength"}},
+		{`{"pattern": "^a+$"}`, `"aab"`, []string{"pattern"}},
+		{`{"enum": ["a", 1, {"b": [null]}]}`, `{"b": [null]}`, nil},
+		{`{"enum": ["a", 1]}`, `"1"`, []string{"enum"}},
+		{`{"const": {"a": 1, "b": 2}}`, `{"b": 2.0, "a": 1}`, nil},
+
+		// uniqueItems compares values, not their encoding.
+		{`{"uniqueItems": true}`, `[1, "1", true, null]`, nil},
+		{`{"uniqueItems": true}`, `[{"a": 1, "b": 2}, {"b": 2, "a": 1}]`, []string{"uniqueItems"}},
+		{`{"uniqueItems": true}`, `[[1, 2], [2, 1]]`, nil},
+		{`{"uniqueItems": false}`, `[1, 1]`, nil},
+
+		{`{"prefixItems": [{"type": "string"}], "items": {"type": "integer"}}`, `["a", 1, 2]`, nil},
+		{`{"prefixItems": [{"type": "string"}], "items": {"type": "integer"}}`, `[1, "a"]`, []string{"type", "type"}},
+		{`{"contains": {"type": "string"}, "minContains": 2}`, `["a", 1]`, []string{"contains"}},
+		{`{"contains": {"type": "string"}, "maxContains": 1}`, `["a", "b"]`, []string{"contains"}},
+		{`{"required": ["a"], "properties": {"a": {"type": "string"}}}`, `{}`, []string{"required"}},
+		{`{"dependentRequired": {"a": ["b"]}}`, `{"a": 1}`, []string{"dependentRequired"}},
+		{`{"properties": {"a": true}, "additionalProperties": false}`, `{"a": 1, "b": 2}`, []string{"additionalProperties"}},
+		{`{"patternProperties": {"^x-": {"type": "string"}}, "additionalProperties": false}`, `{"x-a": "1"}`, nil},
+		{`{"propertyNames": {"maxLength": 2}}`, `{"abc": 1}`, []string{"maxLength"}},
+		{`{"minProperties": 1}`, `{}`, []string{"minProperties"}},
+
+		{`{"anyOf": [{"format": "ipv4"}, {"format": "ipv6"}]}`, `"::1"`, nil},
+		{`{"anyOf": [{"format": "ipv4"}, {"format": "ipv6"}]}`, `"localhost"`, []string{"anyOf"}},
+		{`{"oneOf": [{"type": "integer"}, {"type": "number"}]}`, `1`, []string{"oneOf"}},
+		{`{"oneOf": [{"type": "integer"}, {"type": "number"}]}`, `1.5`, nil},
+		{`{"not": {"const": 1}}`, `1`, []string{"not"}},
+		{`{"if": {"type": "string"}, "then": {"minLength": 2}, "else": {"minimum": 2}}`, `"a"`, []string{"minLength"}},
+		{`{"if": {"type": "string"}, "then": {"minLength": 2}, "else": {"minimum": 2}}`, `1`, []string{"minimum"}},
+	})
+}
+
+func TestFormats(t *testing.T) {
+	runSchemaTests(t, []schemaTest{
+		{`{"format": "email"}`, `"ada@example.com"`, nil},
+		{`{"format": "email"}`, `"ada@"`, []string{"format"}},
+		{`{"format": "ipv4"}`, `"192.168.0.1"`, nil},
+		{`{"format": "ipv4"}`, `"192.168.0.01"`, []string{"format"}},
+		{`{"format": "ipv6"}`, `"2001:db8::1"`, nil},
+		{`{"format": "ipv6"}`, `"10.0.0.1"`, []string{"format"}},
+		{`{"format": "hostname"}`, `"api.example.com"`, nil},
+		{`{"format": "hostname"}`, `"-api.example.com"`, []string{"format"}},
+		{`{"format": "idn-hostname"}`, `"münchen.de"`, nil},
+		{`{"format": "uri"}`, `"https://example.com/a?b#c"`, nil},
+		{`{"format": "uri"}`, `"/relative"`, []string{"format"}},
+		{`{"format": "uri-reference"}`, `"/relative"`, nil},
+		{`{"format": "date-time"}`, `"2026-01-02T03:04:05Z"`, nil},
+		{`{"format": "date-time"}`, `"2026-01-02 03:04:05"`, []string{"format"}},
+		// Unknown formats are annotations, and formats only apply to strings.
+		{`{"format": "uuid"}`, `"not a uuid"`, nil},
+		{`{"format": "email"}`, `42`, nil},
+	})
+}
+
+func TestRefs(t *testing.T) {
+	runSchemaTests(t, []schemaTest{
+		{`{"$defs": {"pos": {"minimum": 0}}, "items": {"$ref": "#/$defs/pos"}}`, `[1, -1]`, []string{"minimum"}},
+		{`{"$defs": {"a": {"$anchor": "x", "type": "string"}}, "$ref": "#x"}`, `1`, []string{"type"}},
+		{`{"$id": "https://example.com/root", "$defs": {"a": {"$id": "leaf", "type": "string"}}, "$ref": "leaf"}`, `"a"`, nil},
+		// Recursive schemas terminate as they descend into the value.
+		{`{"properties": {"next": {"$ref": "#"}}, "required": ["v"]}`, `{"v": 1, "next": {"v": 2, "next": {}}}`, []string{"required"}},
+		// References that never descend stop at the depth limit.
+		{`{"$ref": "#"}`, `1`, []string{"$ref"}},
+		{`{"$defs": {"a": {"$ref": "#/$defs/b"}, "b": {"$ref": "#/$defs/a"}}, "$ref": "#/$defs/a"}`, `{}`, []string{"$ref"}},
+	})
+
+	c, err := Compile([]byte(`{"anyOf": [{"$ref": "#"}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.Validate([]byte(`null`))
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Rule != "anyOf" {
+		t.Errorf("Validate() = %v, want a single anyOf error", err)
+	}
+
+	for _, schema := range []string{`{"$ref": "#/$defs/missing"}`, `{"$ref": "other.json"}`} {
+		if _, err := Compile([]byte(schema)); !errors.Is(err, ErrUnresolvedRef) {
+			t.Errorf("Compile(%s) = %v, want %v", schema, err, ErrUnresolvedRef)
+		}
+	}
+}
+
+func TestErrors(t *testing.T) {
+	for _, schema := range []string{`[]`, `{"type": 1}`, `{"pattern": "(?=a)"}`, `{"minLength": -1}`, `{"allOf": {}}`, `{} {}`} {
+		if _, err := Compile([]byte(schema)); !errors.Is(err, ErrInvalidSchema) {
+			t.Errorf("Compile(%s) = %v, want %v", schema, err, ErrInvalidSchema)
+		}
+	}
+
+	c, err := Compile([]byte(`{"properties": {"a/b": {"properties": {"c~d": {"type": "string"}}}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Validate([]byte(`{"a": `)); !errors.Is(err, ErrInvalidJSON) {
+		t.Errorf("Validate() = %v, want %v", err, ErrInvalidJSON)
+	}
+	// Fields are JSON Pointers, with "/" and "~" escaped.
+	err = c.Validate([]byte(`{"a/b": {"c~d": 1}}`))
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "/a~1b/c~0d" || !errors.Is(errs[0], ErrType) {
+		t.Errorf("Validate() = %v, want a type error at /a~1b/c~0d", err)
+	}
+}
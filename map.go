@@ -0,0 +1,139 @@
+package validator
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Map validates dynamic data, such as a decoded config file or form,
+// against rules keyed by the path of the value they apply to. Paths are
+// dot separated map keys and slice indexes, and "*" stands for every
+// element of a slice or map. Rules use the syntax of validate tags, and
+// cross-value rules such as eqfield take the path of the other value.
+// Failures are reported as ValidationErrors with paths in the form used
+// for structs, e.g. "servers[0].port". Whole numbers held by floats, as
+// encoding/json decodes every number into an interface{}, are checked as
+// integers by the rules that only apply to integers, such as port.
+//
+//	err := val.Map(config, map[string]string{
+//		"name":           "required,alphanum",
+//		"servers.*.host": "required,hostname",
+//		"servers.*.port": "required,min=1,max=65535",
+//	})
+func (v *ValidatorImpl) Map(data map[string]interface{}, rules map[string]string) error {
+	paths := make([]string, 0, len(rules))
+	for path := range rules {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	root := reflect.ValueOf(data)
+	other := func(param string) reflect.Value {
+		if matches := lookup(root, strings.Split(param, "."), ""); len(matches) == 1 {
+			return matches[0].value
+		}
+		return reflect.Value{}
+	}
+	var errs ValidationErrors
+	for _, path := range paths {
+		parsed := ParseTag(rules[path])
+		for _, m := range lookup(root, strings.Split(path, "."), "") {
+			if fe := v.checkValue(v.integral(m.value, parsed), parsed, other); fe != nil {
+				fe.Field = m.path
+				errs = append(errs, fe)
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// integral returns value as an int64 when it is a float holding a whole
+// number and one of rules applies to integers but not to floats.
+func (v *ValidatorImpl) integral(value reflect.Value, rules []TagRule) reflect.Value {
+	value = indirect(value)
+	if k := value.Kind(); k != reflect.Float32 && k != reflect.Float64 {
+		return value
+	}
+	f := value.Float()
+	if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return value
+	}
+	for _, tr := range rules {
+		if r, ok := v.Rule(tr.Name); ok && !r.AppliesTo(value.Kind()) && r.AppliesTo(reflect.Int64) {
+			return reflect.ValueOf(int64(f))
+		}
+	}
+	return value
+}
+
+// match is a value found at a path of dynamic data.
+type match struct {
+	path  string
+	value reflect.Value
+}
+
+// lookup returns the values found at the path made of segments below
+// value. Missing values are returned as invalid values so that required
+// can report them, except below a "*".
+func lookup(value reflect.Value, segments []string, path string) []match {
+	if len(segments) == 0 {
+		return []match{{path: path, value: value}}
+	}
+	seg, rest := segments[0], segments[1:]
+	value = indirect(value)
+	switch value.Kind() {
+	case reflect.Map:
+		if seg == "*" {
+			keys := value.MapKeys()
+			sort.Slice(keys, func(i, j int) bool { return valueString(keys[i]) < valueString(keys[j]) })
+			var matches []match
+			for _, key := range keys {
+				matches = append(matches, lookup(value.MapIndex(key), rest, mapPath(path, valueString(indirect(key)), false))...)
+			}
+			return matches
+		}
+		key := reflect.ValueOf(seg)
+		switch kt := value.Type().Key(); {
+		case kt.Kind() == reflect.String:
+			key = key.Convert(kt)
+		case kt.Kind() != reflect.Interface:
+			return lookup(reflect.Value{}, rest, mapPath(path, seg, false))
+		}
+		return lookup(value.MapIndex(key), rest, mapPath(path, seg, false))
+	case reflect.Slice, reflect.Array:
+		if seg == "*" {
+			var matches []match
+			for i := 0; i < value.Len(); i++ {
+				matches = append(matches, lookup(value.Index(i), rest, mapPath(path, strconv.Itoa(i), true))...)
+			}
+			return matches
+		}
+		if i, err := strconv.Atoi(seg); err == nil && i >= 0 {
+			if i >= value.Len() {
+				return lookup(reflect.Value{}, rest, mapPath(path, seg, true))
+			}
+			return lookup(value.Index(i), rest, mapPath(path, seg, true))
+		}
+	}
+	if seg == "*" {
+		return nil
+	}
+	return lookup(reflect.Value{}, rest, mapPath(path, seg, false))
+}
+
+// mapPath appends a map key or a slice index to a path.
+func mapPath(path, seg string, index bool) string {
+	switch {
+	case index:
+		return path + "[" + seg + "]"
+	case path == "":
+		return seg
+	}
+	return path + "." + seg
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/solrac97gr/validator/validations"
+)
+
+// mapFailures returns the failures of err by field.
+func mapFailures(t *testing.T, err error) map[string]error {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error %v is not ValidationErrors", err)
+	}
+	failures := make(map[string]error, len(errs))
+	for _, fe := range errs {
+		failures[fe.Field] = fe
+	}
+	return failures
+}
+
+func TestMap(t *testing.T) {
+	v := NewValidator()
+	data := map[string]interface{}{
+		"name": "api",
+		"owner": map[string]interface{}{
+			"email": "ops@example.com",
+			"team":  map[string]string{"name": "x"},
+		},
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a.example.com", "port": 8080},
+			map[string]interface{}{"host": "-bad-", "port": 70000},
+		},
+		"password": "s3cr3t",
+		"confirm":  "s3cr3t",
+	}
+	tests := []struct {
+		rules map[string]string
+		want  map[string]error
+	}{
+		{map[string]string{"name": "required,alphanum", "owner.email": "required,email"}, nil},
+		// Dotted paths reach nested maps of any type.
+		{map[string]string{"owner.team.name": "min=2"}, map[string]error{"owner.team.name": ErrMin}},
+		// Missing values only fail required.
+		{map[string]string{"owner.phone": "e164", "owner.team.lead": "required", "missing.deeper": "required"}, map[string]error{
+			"owner.team.lead": ErrRequired,
+			"missing.deeper":  ErrRequired,
+		}},
+		{map[string]string{"servers.*.host": "required,hostname", "servers.*.port": "required,port"}, map[string]error{
+			"servers[1].host": validations.ErrInvalidHostname,
+			"servers[1].port": validations.ErrInvalidPort,
+		}},
+		{map[string]string{"servers.0.port": "eq=8080", "servers.2.port": "required"}, map[string]error{"servers[2].port": ErrRequired}},
+		// Nothing matches a wildcard below a missing value.
+		{map[string]string{"clients.*.host": "required"}, nil},
+		// Cross-value rules take the path of the other value.
+		{map[string]string{"confirm": "eqfield=password", "name": "nefield=servers.0.host"}, nil},
+		{map[string]string{"name": "eqfield=password"}, map[string]error{"name": ErrEq}},
+		{map[string]string{"name": "aplha"}, map[string]error{"name": ErrUnknownRule}},
+	}
+	for _, tt := range tests {
+		got := mapFailures(t, v.Map(data, tt.rules))
+		if len(got) != len(tt.want) {
+			t.Errorf("Map(%v) = %v, want %d failures", tt.rules, got, len(tt.want))
+			continue
+		}
+		for field, want := range tt.want {
+			if !errors.Is(got[field], want) {
+				t.Errorf("Map(%v): %s: %v, want %v", tt.rules, field, got[field], want)
+			}
+		}
+	}
+}
+
+func TestMapJSON(t *testing.T) {
+	v := NewValidator()
+	var data map[string]interface{}
+	doc := `{"port": 8080, "admin_port": 80, "workers": 4.0, "ratio": 0.5, "timeout": 2.5, "big": 1e300,
+		"servers": [{"port": 443}, {"port": 99999}]}`
+	if err := json.Unmarshal([]byte(doc), &data); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		rules map[string]string
+		want  map[string]error
+	}{
+		// Whole numbers decoded as float64 pass the rules on integers.
+		{map[string]string{"port": "port", "workers": "even,multipleof=2", "servers.0.port": "port"}, nil},
+		{map[string]string{"admin_port": "unprivileged_port"}, map[string]error{"admin_port": validations.ErrPrivilegedPort}},
+		{map[string]string{"servers.*.port": "port"}, map[string]error{"servers[1].port": validations.ErrInvalidPort}},
+		{map[string]string{"workers": "odd"}, map[string]error{"workers": ErrNotOdd}},
+		// Rules on floats keep the value as it is.
+		{map[string]string{"ratio": "gt=0,lt=1", "timeout": "min=1,max=5", "port": "min=1,max=65535"}, nil},
+		// Fractions and numbers out of the int64 range are not integers.
+		{map[string]string{"timeout": "port", "ratio": "even", "big": "odd"}, map[string]error{
+			"timeout": ErrNotApplicable,
+			"ratio":   ErrNotApplicable,
+			"big":     ErrNotApplicable,
+		}},
+	}
+	for _, tt := range tests {
+		got := mapFailures(t, v.Map(data, tt.rules))
+		if len(got) != len(tt.want) {
+			t.Errorf("Map(%v) = %v, want %d failures", tt.rules, got, len(tt.want))
+			continue
+		}
+		for field, want := range tt.want {
+			if !errors.Is(got[field], want) {
+				t.Errorf("Map(%v): %s: %v, want %v", tt.rules, field, got[field], want)
+			}
+		}
+	}
+}
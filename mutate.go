@@ -0,0 +1,275 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/solrac97gr/validator/validations"
+)
+
+// ModTagName is the struct tag listing the mutators applied to a field
+// before it is validated.
+const ModTagName = "mod"
+
+var (
+	// ErrUnknownMutator is returned when a field references a mutator that is not registered.
+	ErrUnknownMutator = errors.New("unknown mutator")
+	// ErrNotPointer is returned when mutators must be applied to a struct that was not passed by pointer.
+	ErrNotPointer = errors.New("mutators require a pointer to a struct")
+)
+
+// Mutator normalizes a string value. Mutators must return the value
+// unchanged when they cannot normalize it, leaving the rules to report it.
+type Mutator func(s string) string
+
+var builtinMutators = map[string]Mutator{
+	"trim":          strings.TrimSpace,
+	"lower":         strings.ToLower,
+	"upper":         strings.ToUpper,
+	"collapse":      validations.CollapseSpaces,
+	"strip_control": validations.StripControl,
+	"nfc":           validations.NormalizeNFC,
+	"nfkc":          validations.NormalizeNFKC,
+	"canonical_ip":  validations.NormalizeIPAddress,
+	"canonical_mac": validations.NormalizeMACAddress,
+	"punycode":      validations.NormalizeIDNHostname,
+	"e164":          validations.NormalizeE164PhoneNumber,
+}
+
+// BuiltinMutators returns the names of the mutators every validator knows
+// about, sorted.
+func BuiltinMutators() []string {
+	names := make([]string, 0, len(builtinMutators))
+	for name := range builtinMutators {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RegisterMutator adds a custom mutator, replacing any mutator with the
+// same name.
+func (v *ValidatorImpl) RegisterMutator(name string, m Mutator) error {
+	if name == "" || m == nil {
+		return ErrInvalidRule
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.mutators == nil {
+		v.mutators = make(map[string]Mutator)
+	}
+	v.mutators[name] = m
+	return nil
+}
+
+// mutator returns the mutator registered under name.
+func (v *ValidatorImpl) mutator(name string) (Mutator, bool) {
+	v.mu.RLock()
+	m, ok := v.mutators[name]
+	v.mu.RUnlock()
+	if ok {
+		return m, true
+	}
+	m, ok = builtinMutators[name]
+	return m, ok
+}
+
+// MutationBuilder attaches mutators to the fields of a struct type without
+// using tags.
+type MutationBuilder struct {
+	v *ValidatorImpl
+	t reflect.Type
+}
+
+// Mutations returns a builder attaching mutators to the fields of the type
+// of s, which may be a struct or a pointer to one.
+//
+//	val.Mutations(User{}).
+//		Field("Email", "trim", "lower").
+//		Field("Phone", "e164")
+func (v *ValidatorImpl) Mutations(s interface{}) *MutationBuilder {
+	t := reflect.TypeOf(s)
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return &MutationBuilder{v: v, t: t}
+}
+
+// Field appends mutators to the named field. They run after the ones of
+// the field's mod tag.
+func (b *MutationBuilder) Field(name string, mutators ...string) *MutationBuilder {
+	b.v.mu.Lock()
+	defer b.v.mu.Unlock()
+	if b.v.mutations == nil {
+		b.v.mutations = make(map[reflect.Type]map[string][]string)
+	}
+	if b.v.mutations[b.t] == nil {
+		b.v.mutations[b.t] = make(map[string][]string)
+	}
+	b.v.mutations[b.t][name] = append(b.v.mutations[b.t][name], mutators...)
+	b.v.mutableTypes.Store(new(sync.Map))
+	return b
+}
+
+// Normalize applies the mutators of the fields of s, and of any struct
+// nested in it, in place. s must be a pointer to a struct if it or a
+// struct nested in it has any mutators, otherwise ErrNotPointer is
+// returned. Fields whose mutators fail are reported as ValidationErrors
+// with the rule ModTagName. Types without mutators, such as most generated
+// ones, are only inspected once.
+func (v *ValidatorImpl) Normalize(s interface{}) error {
+	if !v.mutable(reflect.TypeOf(s)) {
+		return nil
+	}
+	rv := reflect.ValueOf(s)
+	if rv.Kind() != reflect.Ptr {
+		return ErrNotPointer
+	}
+	seen := make(ancestors)
+	rv, _ = seen.enter(rv)
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	if errs := v.normalizeStruct(rv, "", seen, nil); len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// mutable reports whether values of type t can have mutators, in their
+// fields or in the structs nested in them. The answer is cached per type
+// until mutators are attached with a MutationBuilder.
+func (v *ValidatorImpl) mutable(t reflect.Type) bool {
+	if t == nil {
+		return false
+	}
+	cache := v.mutableTypes.Load()
+	if cache == nil {
+		v.mutableTypes.CompareAndSwap(nil, new(sync.Map))
+		cache = v.mutableTypes.Load()
+	}
+	if cached, ok := cache.Load(t); ok {
+		return cached.(bool)
+	}
+	found := v.reachesMutators(t, make(map[reflect.Type]bool))
+	cache.Store(t, found)
+	return found
+}
+
+// reachesMutators reports whether t or a struct type nested in it has a
+// field with mutators, skipping the types in seen.
+func (v *ValidatorImpl) reachesMutators(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType || seen[t] {
+		return false
+	}
+	seen[t] = true
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		if len(v.fieldMutators(t, sf)) > 0 || v.reachesMutators(sf.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldMutators returns the names of the mutators of a field.
+func (v *ValidatorImpl) fieldMutators(t reflect.Type, sf reflect.StructField) []string {
+	var names []string
+	for _, name := range strings.Split(sf.Tag.Get(ModTagName), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	v.mu.RLock()
+	names = append(names, v.mutations[t][sf.Name]...)
+	v.mu.RUnlock()
+	return names
+}
+
+// normalizeStruct applies the mutators of every field of the addressable
+// struct rv, appending the fields whose mutators fail to errs.
+func (v *ValidatorImpl) normalizeStruct(rv reflect.Value, prefix string, seen ancestors, errs ValidationErrors) ValidationErrors {
+	t := rv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		path := sf.Name
+		if prefix != "" {
+			path = prefix + "." + sf.Name
+		}
+		fv := rv.Field(i)
+		if names := v.fieldMutators(t, sf); len(names) > 0 {
+			if err := v.mutate(fv, names); err != nil {
+				errs = append(errs, &FieldError{Field: path, Rule: ModTagName, Param: strings.Join(names, ","), Err: err})
+				continue
+			}
+		}
+		errs = v.normalizeNested(fv, path, seen, errs)
+	}
+	return errs
+}
+
+// normalizeNested applies the mutators of structs held by value, skipping
+// values already being normalized by an enclosing call.
+func (v *ValidatorImpl) normalizeNested(value reflect.Value, path string, seen ancestors, errs ValidationErrors) ValidationErrors {
+	value, entered := seen.enter(value)
+	defer seen.leave(entered)
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type() != timeType && value.CanSet() {
+			return v.normalizeStruct(value, path, seen, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			errs = v.normalizeNested(value.Index(i), path+"["+strconv.Itoa(i)+"]", seen, errs)
+		}
+	}
+	return errs
+}
+
+// mutate applies the named mutators to a string, a pointer to a string or
+// a slice of strings.
+func (v *ValidatorImpl) mutate(value reflect.Value, names []string) error {
+	mutators := make([]Mutator, len(names))
+	for i, name := range names {
+		m, ok := v.mutator(name)
+		if !ok {
+			return fmt.Errorf("%w %q", ErrUnknownMutator, name)
+		}
+		mutators[i] = m
+	}
+	apply := func(s reflect.Value) {
+		str := s.String()
+		for _, m := range mutators {
+			str = m(str)
+		}
+		s.SetString(str)
+	}
+
+	value = indirect(value)
+	switch {
+	case !value.IsValid():
+	case value.Kind() == reflect.String:
+		apply(value)
+	case (value.Kind() == reflect.Slice || value.Kind() == reflect.Array) && value.Type().Elem().Kind() == reflect.String:
+		for i := 0; i < value.Len(); i++ {
+			apply(value.Index(i))
+		}
+	default:
+		return fmt.Errorf("%w: mutators on %s", ErrNotApplicable, value.Kind())
+	}
+	return nil
+}
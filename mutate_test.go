@@ -0,0 +1,203 @@
+package validator
+
+import (
+	"errors"
+	"testing"
+)
+
+type contact struct {
+	Email   string   `mod:"trim,lower" validate:"required,email"`
+	Phone   *string  `mod:"e164"`
+	Aliases []string `mod:"trim"`
+	Name    string
+	Home    place
+	Work    *place
+	Places  []place
+	Next    *contact
+}
+
+func (c *contact) Validate(...interface{}) error { return nil }
+
+type place struct {
+	City string `mod:" collapse , upper "`
+}
+
+// plain has no mutators, directly or in its nested structs.
+type plain struct {
+	Name string `validate:"required"`
+	Tags []string
+}
+
+func (p plain) Validate(...interface{}) error { return nil }
+
+func TestNormalize(t *testing.T) {
+	v := NewValidator()
+	phone := "+1 (415) 555-2671"
+	c := &contact{
+		Email:   "  Ada@Example.COM ",
+		Phone:   &phone,
+		Aliases: []string{" ada ", "lovelace "},
+		Name:    "  untouched ",
+		Home:    place{City: "new   york"},
+		Work:    &place{City: "  san  francisco"},
+		Places:  []place{{City: "paris"}, {City: "la  paz"}},
+	}
+	c.Next = c
+	if err := v.Normalize(c); err != nil {
+		t.Fatalf("Normalize() = %v", err)
+	}
+	want := contact{
+		Email:   "ada@example.com",
+		Aliases: []string{"ada", "lovelace"},
+		Name:    "  untouched ",
+		Home:    place{City: "NEW YORK"},
+		Work:    &place{City: "SAN FRANCISCO"},
+		Places:  []place{{City: "PARIS"}, {City: "LA PAZ"}},
+	}
+	if c.Email != want.Email || c.Name != want.Name || c.Home != want.Home || *c.Work != *want.Work {
+		t.Errorf("Normalize() = %+v, want %+v", c, want)
+	}
+	if *c.Phone != "+14155552671" {
+		t.Errorf("Phone = %q, want %q", *c.Phone, "+14155552671")
+	}
+	for i := range want.Aliases {
+		if c.Aliases[i] != want.Aliases[i] {
+			t.Errorf("Aliases[%d] = %q, want %q", i, c.Aliases[i], want.Aliases[i])
+		}
+	}
+	for i := range want.Places {
+		if c.Places[i] != want.Places[i] {
+			t.Errorf("Places[%d] = %+v, want %+v", i, c.Places[i], want.Places[i])
+		}
+	}
+
+	// Mutators run before the rules.
+	if err := v.Struct(&contact{Email: " ADA@example.com"}); err != nil {
+		t.Errorf("Struct() = %v, want nil", err)
+	}
+	if err := v.Normalize(&contact{}); err != nil {
+		t.Errorf("Normalize() of a zero value = %v", err)
+	}
+	if err := v.Normalize((*contact)(nil)); err != nil {
+		t.Errorf("Normalize() of a nil pointer = %v", err)
+	}
+}
+
+func TestNormalizeNotPointer(t *testing.T) {
+	v := NewValidator()
+	tests := []struct {
+		name  string
+		value interface{}
+		want  error
+	}{
+		{"top-level mutators", contact{}, ErrNotPointer},
+		// Mutators of nested structs would be applied to a copy as well.
+		{"nested mutators", struct{ Home place }{}, ErrNotPointer},
+		{"nested through a pointer", struct{ Work *place }{}, ErrNotPointer},
+		{"nested in a slice", struct{ Places []place }{}, ErrNotPointer},
+		{"no mutators", plain{Name: "x"}, nil},
+		{"not a struct", "  x ", nil},
+		{"nil", nil, nil},
+	}
+	for _, tt := range tests {
+		if err := v.Normalize(tt.value); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Normalize() = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+	if err := v.Struct(plain{Name: "x"}); err != nil {
+		t.Errorf("Struct() of a value without mutators = %v, want nil", err)
+	}
+}
+
+func TestNormalizeErrors(t *testing.T) {
+	type bad struct {
+		Name  string `mod:"trim,titlecase"`
+		Count int    `mod:"trim"`
+		Home  place
+		Inner struct {
+			Code string `mod:"shout"`
+		}
+	}
+	v := NewValidator()
+	b := &bad{Name: " ada ", Home: place{City: "rome"}}
+	err := v.Normalize(b)
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("Normalize() = %v, want ValidationErrors", err)
+	}
+	want := []struct {
+		field, param string
+		err          error
+	}{
+		{"Name", "trim,titlecase", ErrUnknownMutator},
+		{"Count", "trim", ErrNotApplicable},
+		{"Inner.Code", "shout", ErrUnknownMutator},
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("Normalize() = %v, want %d errors", err, len(want))
+	}
+	for i, w := range want {
+		fe := errs[i]
+		if fe.Field != w.field || fe.Rule != ModTagName || fe.Param != w.param || !errors.Is(fe, w.err) {
+			t.Errorf("error %d = %s %s=%s: %v, want %s %s=%s: %v", i, fe.Field, fe.Rule, fe.Param, fe.Err, w.field, ModTagName, w.param, w.err)
+		}
+	}
+	// A failing field is left unchanged; the others are still normalized.
+	if b.Name != " ada " || b.Home.City != "ROME" {
+		t.Errorf("Normalize() = %+v", b)
+	}
+}
+
+func TestMutations(t *testing.T) {
+	v := NewValidator()
+	c := &contact{Name: "  ada  lovelace "}
+	// The type is cached as mutable before the builder runs.
+	if err := v.Normalize(c); err != nil || c.Name != "  ada  lovelace " {
+		t.Fatalf("Normalize() = %v, %q", err, c.Name)
+	}
+	v.Mutations(&contact{}).Field("Name", "trim").Field("Name", "collapse")
+	v.Mutations(place{}).Field("City", "trim")
+	if err := v.Normalize(c); err != nil || c.Name != "ada lovelace" {
+		t.Errorf("Normalize() = %v, %q, want %q", err, c.Name, "ada lovelace")
+	}
+	// Builder mutators run after the tag ones.
+	p := &struct{ Home place }{place{City: " a  b "}}
+	if err := v.Normalize(p); err != nil || p.Home.City != "A B" {
+		t.Errorf("Normalize() = %v, %q, want %q", err, p.Home.City, "A B")
+	}
+
+	// Attaching mutators makes a type without any mutable.
+	if err := v.Normalize(plain{}); err != nil {
+		t.Fatal(err)
+	}
+	v.Mutations(plain{}).Field("Name", "upper")
+	if err := v.Normalize(plain{}); !errors.Is(err, ErrNotPointer) {
+		t.Errorf("Normalize() = %v, want %v", err, ErrNotPointer)
+	}
+	pl := &plain{Name: "x"}
+	if err := v.Normalize(pl); err != nil || pl.Name != "X" {
+		t.Errorf("Normalize() = %v, %q, want %q", err, pl.Name, "X")
+	}
+}
+
+func TestRegisterMutator(t *testing.T) {
+	v := NewValidator()
+	if err := v.RegisterMutator("", func(s string) string { return s }); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("RegisterMutator(\"\") = %v, want %v", err, ErrInvalidRule)
+	}
+	if err := v.RegisterMutator("shout", nil); !errors.Is(err, ErrInvalidRule) {
+		t.Errorf("RegisterMutator(nil) = %v, want %v", err, ErrInvalidRule)
+	}
+	if err := v.RegisterMutator("trim", func(s string) string { return "custom" }); err != nil {
+		t.Fatal(err)
+	}
+	c := &contact{Email: " x@example.com"}
+	if err := v.Normalize(c); err != nil || c.Email != "custom" {
+		t.Errorf("Normalize() = %v, %q, want the custom trim", err, c.Email)
+	}
+	// Other validators keep the builtin.
+	c = &contact{Email: " x@example.com"}
+	if err := NewValidator().Normalize(c); err != nil || c.Email != "x@example.com" {
+		t.Errorf("Normalize() = %v, %q", err, c.Email)
+	}
+}
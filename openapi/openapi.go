@@ -0,0 +1,96 @@
+// Package openapi generates OpenAPI 3.1 component schemas from the
+// validation rules of Go structs.
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/solrac97gr/validator/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the OpenAPI version of the generated documents.
+const Version = "3.1.0"
+
+// ErrNotNamedStruct is returned when a type given to Generate is not a named struct.
+var ErrNotNamedStruct = errors.New("component types must be named structs")
+
+// Document is an OpenAPI document holding only component schemas.
+type Document struct {
+	OpenAPI           string     `json:"openapi"`
+	Info              Info       `json:"info"`
+	JSONSchemaDialect string     `json:"jsonSchemaDialect,omitempty"`
+	Components        Components `json:"components"`
+}
+
+// Info holds the metadata of the API.
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Components holds the reusable objects of the document.
+type Components struct {
+	Schemas map[string]*jsonschema.Schema `json:"schemas"`
+}
+
+// Generate returns a document with a component schema for each of the
+// given types and for every named struct they reference.
+func Generate(info Info, types ...interface{}) (*Document, error) {
+	g := &jsonschema.Generator{RefPrefix: "#/components/schemas/"}
+	for _, v := range types {
+		t := reflect.TypeOf(v)
+		for t != nil && t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t == nil || t.Kind() != reflect.Struct || t.Name() == "" {
+			return nil, fmt.Errorf("%w: %v", ErrNotNamedStruct, t)
+		}
+		if _, err := g.Schema(t); err != nil {
+			return nil, err
+		}
+	}
+
+	schemas := g.Defs()
+	if schemas == nil {
+		schemas = make(map[string]*jsonschema.Schema)
+	}
+	return &Document{
+		OpenAPI:           Version,
+		Info:              info,
+		JSONSchemaDialect: jsonschema.Draft,
+		Components:        Components{Schemas: schemas},
+	}, nil
+}
+
+// JSON returns the indented JSON encoding of the document.
+func (d *Document) JSON() ([]byte, error) {
+	return json.MarshalIndent(d, "", "  ")
+}
+
+// YAML returns the YAML encoding of the document. Keys keep the order of
+// the JSON encoding.
+func (d *Document) YAML() ([]byte, error) {
+	b, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	var node yaml.Node
+	if err := yaml.Unmarshal(b, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+	return yaml.Marshal(&node)
+}
+
+// blockStyle resets the flow and quoting styles kept from the JSON input so
+// the document is rendered in block style.
+func blockStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, c := range n.Content {
+		blockStyle(c)
+	}
+}
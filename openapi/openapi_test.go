@@ -0,0 +1,213 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/solrac97gr/validator/jsonschema"
+	"gopkg.in/yaml.v3"
+)
+
+type Item struct {
+	SKU string `json:"sku" validate:"required,len=8"`
+	Qty int    `json:"qty" validate:"oneof=1 2"`
+}
+
+type Order struct {
+	ID    string `json:"id" validate:"required"`
+	Flag  string `json:"flag" validate:"oneof=1 true null"`
+	Items []Item `json:"items" validate:"min=1"`
+}
+
+const orderJSON = `{
+  "openapi": "3.1.0",
+  "info": {"title": "Shop", "version": "1.0.0"},
+  "jsonSchemaDialect": "https://json-schema.org/draft/2020-12/schema",
+  "components": {
+    "schemas": {
+      "Item": {
+        "type": "object",
+        "properties": {
+          "qty": {"type": "integer", "enum": [1, 2]},
+          "sku": {"type": "string", "minLength": 8, "maxLength": 8}
+        },
+        "required": ["sku"]
+      },
+      "Order": {
+        "type": "object",
+        "properties": {
+          "flag": {"type": "string", "enum": ["1", "true", "null"]},
+          "id": {"type": "string"},
+          "items": {"type": "array", "items": {"$ref": "#/components/schemas/Item"}, "minItems": 1}
+        },
+        "required": ["id"]
+      }
+    }
+  }
+}`
+
+const orderYAML = `openapi: 3.1.0
+info:
+    title: Shop
+    version: 1.0.0
+jsonSchemaDialect: https://json-schema.org/draft/2020-12/schema
+components:
+    schemas:
+        Item:
+            type: object
+            properties:
+                qty:
+                    type: integer
+                    enum:
+                        - 1
+                        - 2
+                sku:
+                    type: string
+                    minLength: 8
+                    maxLength: 8
+            required:
+                - sku
+        Order:
+            type: object
+            properties:
+                flag:
+                    type: string
+                    enum:
+                        - "1"
+                        - "true"
+                        - "null"
+                id:
+                    type: string
+                items:
+                    type: array
+                    items:
+                        $ref: '#/components/schemas/Item'
+                    minItems: 1
+            required:
+                - id
+`
+
+func TestGenerate(t *testing.T) {
+	doc, err := Generate(Info{Title: "Shop", Version: "1.0.0"}, &Order{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := doc.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got, want interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(orderJSON), &want); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("JSON() =\n%s\nwant\n%s", b, orderJSON)
+	}
+
+	y, err := doc.YAML()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(y) != orderYAML {
+		t.Errorf("YAML() =\n%s\nwant\n%s", y, orderYAML)
+	}
+	// Both encodings hold the same document.
+	var decoded interface{}
+	if err := yaml.Unmarshal(y, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if normalized := normalize(decoded); !reflect.DeepEqual(normalized, got) {
+		t.Errorf("YAML() decodes to %v, want %v", normalized, got)
+	}
+}
+
+func TestGenerateEmpty(t *testing.T) {
+	doc, err := Generate(Info{Title: "Empty", Version: "0"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := doc.JSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Components.Schemas == nil || len(got.Components.Schemas) != 0 {
+		t.Errorf("JSON() = %s, want empty schemas", b)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	for _, v := range []interface{}{nil, 42, []Order{}, struct{ ID string }{}, new(*int)} {
+		if _, err := Generate(Info{}, v); !errors.Is(err, ErrNotNamedStruct) {
+			t.Errorf("Generate(%T) = %v, want %v", v, err, ErrNotNamedStruct)
+		}
+	}
+	type bad struct {
+		N int `validate:"min=abc"`
+	}
+	if _, err := Generate(Info{}, bad{}); !errors.Is(err, jsonschema.ErrInvalidParam) {
+		t.Errorf("Generate() = %v, want %v", err, jsonschema.ErrInvalidParam)
+	}
+}
+
+func TestBlockStyle(t *testing.T) {
+	var node yaml.Node
+	doc := `{"strings": ["1", "true", "null", "x", "1.0.0", ""], "scalars": [1, true, null], "flow": {"a": "b"}}`
+	if err := yaml.Unmarshal([]byte(doc), &node); err != nil {
+		t.Fatal(err)
+	}
+	blockStyle(&node)
+	b, err := yaml.Marshal(&node)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Strings that would read as other types keep their quotes.
+	want := `strings:
+    - "1"
+    - "true"
+    - "null"
+    - x
+    - 1.0.0
+    - ""
+scalars:
+    - 1
+    - true
+    - null
+flow:
+    a: b
+`
+	if string(b) != want {
+		t.Errorf("blockStyle() =\n%s\nwant\n%s", b, want)
+	}
+}
+
+// normalize converts the numbers decoded from YAML to float64, as JSON
+// decodes them.
+func normalize(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, e := range v {
+			v[k] = normalize(e)
+		}
+	case []interface{}:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+	case int:
+		return float64(v)
+	}
+	return v
+}
@@ -0,0 +1,343 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownField is returned when a field mask or a JSON key names a field that does not exist.
+var ErrUnknownField = errors.New("unknown field")
+
+// StructPartial validates only the named fields of s, as needed for PATCH
+// requests. Fields are given by their path, e.g. "Address.City"; naming a
+// struct field selects every field nested in it, and indexes are left out
+// of paths, so "Items.Name" selects the name of every item. The struct's
+// own Validate method is not called unless it was generated.
+func (v *ValidatorImpl) StructPartial(s EvaluableStruct, fields ...string) error {
+	return v.validatePaths(s, func(path string) bool { return selected(fields, path) })
+}
+
+// StructExcept validates every field of s but the named ones. Fields are
+// given as for StructPartial.
+func (v *ValidatorImpl) StructExcept(s EvaluableStruct, fields ...string) error {
+	return v.validatePaths(s, func(path string) bool { return !selected(fields, path) })
+}
+
+// StructPresent validates the fields of s present in raw, the JSON object s
+// was decoded from, such as a JSON Merge Patch document. Keys are matched
+// to fields as encoding/json does: by json tag or field name, preferring
+// an exact match to a case-insensitive one, with the fields of embedded
+// structs promoted. Nested objects select the fields present in them.
+// ErrUnknownField is returned for keys that match no field, which
+// json.Unmarshal would silently drop.
+//
+//	var raw map[string]json.RawMessage
+//	json.Unmarshal(body, &raw)
+//	json.Unmarshal(body, &user)
+//	err := val.StructPresent(&user, raw)
+func (v *ValidatorImpl) StructPresent(s EvaluableStruct, raw map[string]json.RawMessage) error {
+	paths, err := jsonPaths(reflect.TypeOf(s), raw, "", "")
+	if err != nil {
+		return err
+	}
+	return v.StructPartial(s, paths...)
+}
+
+// StructMask validates the fields of s selected by a protobuf-style field
+// mask, such as the Paths of a google.protobuf.FieldMask. Each path is a
+// dot separated list of json tag names, or field names for the fields
+// without one, with the fields of embedded structs promoted. Names are
+// case sensitive. ErrUnknownField is returned for paths that don't name a
+// field of s.
+//
+//	err := val.StructMask(req.GetUser(), req.GetUpdateMask().GetPaths()...)
+func (v *ValidatorImpl) StructMask(s EvaluableStruct, paths ...string) error {
+	fields := make([]string, len(paths))
+	for i, path := range paths {
+		field, err := maskPath(reflect.TypeOf(s), path)
+		if err != nil {
+			return err
+		}
+		fields[i] = field
+	}
+	return v.StructPartial(s, fields...)
+}
+
+// validatePaths validates s and keeps the failures of the fields whose
+// path is accepted by keep.
+func (v *ValidatorImpl) validatePaths(s EvaluableStruct, keep func(path string) bool) error {
+	if err := v.Normalize(s); err != nil {
+		return err
+	}
+	var err error
+	if generated(s) && !v.overridden(s) {
+		err = s.Validate()
+	} else {
+		err = v.validateFields(s, Groups{DefaultGroup})
+	}
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		var fe *FieldError
+		if !errors.As(err, &fe) {
+			return err
+		}
+		errs = ValidationErrors{fe}
+	}
+	var kept ValidationErrors
+	for _, fe := range errs {
+		if keep(stripIndexes(fe.Field)) {
+			kept = append(kept, fe)
+		}
+	}
+	if len(kept) > 0 {
+		return kept
+	}
+	return nil
+}
+
+// selected reports whether path is one of fields or nested in one of them.
+func selected(fields []string, path string) bool {
+	for _, f := range fields {
+		if path == f || strings.HasPrefix(path, f+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// stripIndexes removes the slice indexes and map keys from a field path,
+// turning "Items[0].Name" into "Items.Name".
+func stripIndexes(path string) string {
+	if !strings.Contains(path, "[") {
+		return path
+	}
+	var b strings.Builder
+	depth := 0
+	for _, r := range path {
+		switch {
+		case r == '[':
+			depth++
+		case r == ']' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// jsonPaths returns the paths of the fields of t present in raw. prefix
+// is the path of t and keyPrefix the keys leading to raw.
+func jsonPaths(t reflect.Type, raw map[string]json.RawMessage, prefix, keyPrefix string) ([]string, error) {
+	t = derefType(t)
+	if t.Kind() != reflect.Struct {
+		return nil, nil
+	}
+	keys := make([]string, 0, len(raw))
+	for key := range raw {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var paths []string
+	for _, key := range keys {
+		f, ok := jsonFieldByName(t, key, true)
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrUnknownField, keyPrefix+key)
+		}
+		path := prefix + f.path
+		ft := derefType(f.typ)
+		msg := raw[key]
+		if ft.Kind() != reflect.Struct || ft == timeType || !bytes.HasPrefix(bytes.TrimSpace(msg), []byte("{")) {
+			paths = append(paths, path)
+			continue
+		}
+		var nested map[string]json.RawMessage
+		if err := json.Unmarshal(msg, &nested); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		nestedPaths, err := jsonPaths(ft, nested, path+".", keyPrefix+key+".")
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, nestedPaths...)
+	}
+	return paths, nil
+}
+
+// maskPath turns a field mask path into the path of the field it names.
+func maskPath(t reflect.Type, path string) (string, error) {
+	var fields []string
+	for _, name := range strings.Split(path, ".") {
+		t = elemType(t)
+		if t.Kind() != reflect.Struct {
+			return "", fmt.Errorf("%w %q", ErrUnknownField, path)
+		}
+		f, ok := jsonFieldByName(t, name, false)
+		if !ok {
+			return "", fmt.Errorf("%w %q", ErrUnknownField, path)
+		}
+		fields = append(fields, f.path)
+		t = f.typ
+	}
+	return strings.Join(fields, "."), nil
+}
+
+// jsonField is a field of a struct type as encoding/json sees it.
+type jsonField struct {
+	name   string // the key it is encoded under
+	path   string // the dot separated names of the fields leading to it
+	typ    reflect.Type
+	index  []int
+	tagged bool
+}
+
+// jsonFieldCache holds the []jsonField of struct types.
+var jsonFieldCache sync.Map
+
+// jsonFieldByName returns the field of the struct type t encoded under
+// name. With fold, a case-insensitive match is accepted when no field has
+// exactly that name, as json.Unmarshal does.
+func jsonFieldByName(t reflect.Type, name string, fold bool) (jsonField, bool) {
+	fields := jsonFields(t)
+	for _, f := range fields {
+		if f.name == name {
+			return f, true
+		}
+	}
+	if fold {
+		for _, f := range fields {
+			if strings.EqualFold(f.name, name) {
+				return f, true
+			}
+		}
+	}
+	return jsonField{}, false
+}
+
+// jsonFields returns the fields of the struct type t encoded by
+// encoding/json, in the order of their index. The exported fields of
+// embedded structs without a json name are promoted; when several fields
+// share a name, the least nested one wins, then the tagged one, and the
+// name is dropped if that leaves more than one.
+func jsonFields(t reflect.Type) []jsonField {
+	if cached, ok := jsonFieldCache.Load(t); ok {
+		return cached.([]jsonField)
+	}
+	type embedded struct {
+		typ   reflect.Type
+		path  string
+		index []int
+	}
+	var (
+		all     []jsonField
+		depths  = make(map[string]int)
+		visited = make(map[reflect.Type]bool)
+		next    = []embedded{{typ: t}}
+	)
+	for depth := 0; len(next) > 0; depth++ {
+		current := next
+		next = nil
+		for _, e := range current {
+			if visited[e.typ] {
+				continue
+			}
+			visited[e.typ] = true
+			for i := 0; i < e.typ.NumField(); i++ {
+				sf := e.typ.Field(i)
+				ft := derefType(sf.Type)
+				if sf.Anonymous && !sf.IsExported() && ft.Kind() != reflect.Struct || !sf.Anonymous && !sf.IsExported() {
+					continue
+				}
+				tag := sf.Tag.Get("json")
+				if tag == "-" {
+					continue
+				}
+				name, _, _ := strings.Cut(tag, ",")
+				path := sf.Name
+				if e.path != "" {
+					path = e.path + "." + sf.Name
+				}
+				index := append(append([]int(nil), e.index...), i)
+				if name == "" && sf.Anonymous && ft.Kind() == reflect.Struct {
+					next = append(next, embedded{typ: ft, path: path, index: index})
+					continue
+				}
+				if !sf.IsExported() {
+					continue
+				}
+				f := jsonField{name: name, path: path, typ: sf.Type, index: index, tagged: name != ""}
+				if f.name == "" {
+					f.name = sf.Name
+				}
+				if _, ok := depths[f.name]; !ok {
+					depths[f.name] = depth
+				}
+				all = append(all, f)
+			}
+		}
+	}
+
+	// Keep the dominant field of each name.
+	var fields []jsonField
+	for _, f := range all {
+		var rivals []jsonField
+		for _, g := range all {
+			if g.name == f.name && len(g.index) == len(f.index) {
+				rivals = append(rivals, g)
+			}
+		}
+		if len(f.index)-1 != depths[f.name] {
+			continue
+		}
+		if len(rivals) > 1 {
+			tagged := 0
+			for _, g := range rivals {
+				if g.tagged {
+					tagged++
+				}
+			}
+			if !f.tagged || tagged != 1 {
+				continue
+			}
+		}
+		fields = append(fields, f)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		a, b := fields[i].index, fields[j].index
+		for k := 0; k < len(a) && k < len(b); k++ {
+			if a[k] != b[k] {
+				return a[k] < b[k]
+			}
+		}
+		return len(a) < len(b)
+	})
+	jsonFieldCache.Store(t, fields)
+	return fields
+}
+
+// derefType dereferences pointer types.
+func derefType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+// elemType dereferences pointer types and returns the element type of
+// slices, arrays and maps, down to the type of the values they hold.
+func elemType(t reflect.Type) reflect.Type {
+	for {
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+			t = t.Elem()
+		default:
+			return t
+		}
+	}
+}
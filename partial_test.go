@@ -0,0 +1,235 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+type audit struct {
+	Editor string `json:"editor" validate:"required"`
+}
+
+type Owner struct {
+	Email string `json:"email" validate:"required,email"`
+}
+
+type postal struct {
+	Code string `json:"code" validate:"len=5"`
+}
+
+type profile struct {
+	audit
+	*Owner
+	Name    string            `json:"name" validate:"required,min=3"`
+	Age     int               `json:"age" validate:"gte=18"`
+	Home    postal            `json:"home"`
+	Mailing []postal          `json:"mailing"`
+	Notes   map[string]string `json:"notes,omitempty" validate:"max=2"`
+	Secret  string            `json:"-" validate:"required"`
+}
+
+func (p *profile) Validate(...interface{}) error { return nil }
+
+// failedFields returns the sorted fields of the failures in err.
+func failedFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var errs ValidationErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("error %v is not ValidationErrors", err)
+	}
+	var fields []string
+	for _, fe := range errs {
+		fields = append(fields, fe.Field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// invalidProfile fails every rule of profile but the one of Notes.
+func invalidProfile() *profile {
+	return &profile{
+		Owner:   &Owner{Email: "nope"},
+		Name:    "Al",
+		Age:     12,
+		Home:    postal{Code: "123"},
+		Mailing: []postal{{Code: "12345"}, {Code: "1"}},
+	}
+}
+
+func TestStructPartial(t *testing.T) {
+	v := NewValidator()
+	tests := []struct {
+		fields []string
+		want   []string
+	}{
+		{nil, nil},
+		{[]string{"Name"}, []string{"Name"}},
+		{[]string{"Name", "Age"}, []string{"Age", "Name"}},
+		// A struct field selects the fields nested in it.
+		{[]string{"Home"}, []string{"Home.Code"}},
+		{[]string{"Home.Code"}, []string{"Home.Code"}},
+		// Paths leave indexes out.
+		{[]string{"Mailing.Code"}, []string{"Mailing[1].Code"}},
+		{[]string{"Owner"}, []string{"Owner.Email"}},
+		{[]string{"audit.Editor"}, []string{"audit.Editor"}},
+		// Prefixes only match whole names.
+		{[]string{"Nam", "Hom"}, nil},
+	}
+	for _, tt := range tests {
+		got := failedFields(t, v.StructPartial(invalidProfile(), tt.fields...))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("StructPartial(%q) failed %q, want %q", tt.fields, got, tt.want)
+		}
+	}
+}
+
+func TestStructExcept(t *testing.T) {
+	v := NewValidator()
+	err := v.StructExcept(invalidProfile(), "Home", "Mailing.Code", "Secret", "audit")
+	want := []string{"Age", "Name", "Owner.Email"}
+	if got := failedFields(t, err); !reflect.DeepEqual(got, want) {
+		t.Errorf("StructExcept() failed %q, want %q", got, want)
+	}
+}
+
+func TestStructPresent(t *testing.T) {
+	v := NewValidator()
+	tests := []struct {
+		doc  string
+		want []string
+	}{
+		{`{}`, nil},
+		{`{"name": "Al"}`, []string{"Name"}},
+		// Keys match case-insensitively, as json.Unmarshal does.
+		{`{"NAME": "Al", "Age": 12}`, []string{"Age", "Name"}},
+		// Nested objects select the fields present in them.
+		{`{"home": {"code": "123"}}`, []string{"Home.Code"}},
+		{`{"home": {}}`, nil},
+		{`{"home": null}`, []string{"Home.Code"}},
+		{`{"mailing": [{"code": "1"}]}`, []string{"Mailing[1].Code"}},
+		// Fields of embedded structs are promoted, exported or not.
+		{`{"email": "nope"}`, []string{"Owner.Email"}},
+		{`{"editor": ""}`, []string{"audit.Editor"}},
+	}
+	for _, tt := range tests {
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(tt.doc), &raw); err != nil {
+			t.Fatal(err)
+		}
+		got := failedFields(t, v.StructPresent(invalidProfile(), raw))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("StructPresent(%s) failed %q, want %q", tt.doc, got, tt.want)
+		}
+	}
+
+	for _, doc := range []string{`{"nickname": "x"}`, `{"Secret": "x"}`, `{"home": {"zip": "1"}}`} {
+		var raw map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(doc), &raw); err != nil {
+			t.Fatal(err)
+		}
+		if err := v.StructPresent(invalidProfile(), raw); !errors.Is(err, ErrUnknownField) {
+			t.Errorf("StructPresent(%s) = %v, want %v", doc, err, ErrUnknownField)
+		}
+	}
+}
+
+func TestStructMask(t *testing.T) {
+	v := NewValidator()
+	tests := []struct {
+		paths []string
+		want  []string
+	}{
+		{[]string{"name"}, []string{"Name"}},
+		{[]string{"home.code", "age"}, []string{"Age", "Home.Code"}},
+		{[]string{"mailing.code"}, []string{"Mailing[1].Code"}},
+		{[]string{"email", "editor"}, []string{"Owner.Email", "audit.Editor"}},
+		{[]string{"notes"}, nil},
+	}
+	for _, tt := range tests {
+		got := failedFields(t, v.StructMask(invalidProfile(), tt.paths...))
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("StructMask(%q) failed %q, want %q", tt.paths, got, tt.want)
+		}
+	}
+
+	// Masks are case sensitive and name JSON keys, not Go fields.
+	for _, path := range []string{"Name", "home.Code", "Home", "name.first", "mailing.zip"} {
+		if err := v.StructMask(invalidProfile(), path); !errors.Is(err, ErrUnknownField) {
+			t.Errorf("StructMask(%q) = %v, want %v", path, err, ErrUnknownField)
+		}
+	}
+}
+
+type Named struct {
+	Name string
+}
+
+type Handle struct {
+	Name string
+}
+
+type Label struct {
+	Name string `json:"Name"`
+}
+
+func TestJSONFields(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  map[string]string // key to field path
+	}{
+		{"least nested wins", struct {
+			Named
+			Name string `json:"Name"`
+		}{}, map[string]string{"Name": "Name"}},
+		{"tagged wins at the same depth", struct {
+			Named
+			Label
+		}{}, map[string]string{"Name": "Label.Name"}},
+		{"ambiguous names are dropped", struct {
+			Named
+			Handle
+			Other string `json:"other"`
+		}{}, map[string]string{"other": "Other"}},
+		{"tagged embedded structs are not promoted", struct {
+			Label `json:"label"`
+		}{}, map[string]string{"label": "Label"}},
+		{"unexported embedded structs promote", struct {
+			audit
+			hidden string
+			Skip   string `json:"-"`
+		}{}, map[string]string{"editor": "audit.Editor"}},
+	}
+	for _, tt := range tests {
+		got := make(map[string]string)
+		for _, f := range jsonFields(reflect.TypeOf(tt.value)) {
+			got[f.name] = f.path
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: jsonFields() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStripIndexes(t *testing.T) {
+	tests := []struct {
+		path, want string
+	}{
+		{"Name", "Name"},
+		{"Items[0].Name", "Items.Name"},
+		{"Matrix[1][2]", "Matrix"},
+		{"Labels[a.b].Value", "Labels.Value"},
+		{"Labels[[x]].Value", "Labels.Value"},
+	}
+	for _, tt := range tests {
+		if got := stripIndexes(tt.path); got != tt.want {
+			t.Errorf("stripIndexes(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package validator
+
+import (
+	"context"
+	"reflect"
+	"time"
+
+	"github.com/solrac97gr/validator/validations"
+)
+
+// FQDNExistsRule returns a rule named "fqdn_exists" checking that a string
+// is a fully qualified domain name that resolves with r, giving up after
+// timeout. A nil r uses the system resolver. The fqdn rule only checks the
+// syntax; register this one to also hit DNS:
+//
+//	val.RegisterRule(validator.FQDNExistsRule(nil, 2*time.Second))
+func FQDNExistsRule(r validations.Resolver, timeout time.Duration) Rule {
+	return Rule{
+		Name:  "fqdn_exists",
+		Kinds: stringKinds,
+		Func: func(value reflect.Value, _ string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			return validations.LookupFQDN(ctx, r, value.String())
+		},
+	}
+}
+
+// URLPolicyRule returns a rule with the given name checking that a string
+// is a URL accepted by p. When p has a Resolver, the host is looked up,
+// giving up after timeout. The safe_url rule checks against the zero
+// URLPolicy; register a stricter one for webhooks and the like:
+//
+//	val.RegisterRule(validator.URLPolicyRule("webhook_url", validations.URLPolicy{
+//		Schemes:  []string{"https"},
+//		Resolver: net.DefaultResolver,
+//	}, 2*time.Second))
+func URLPolicyRule(name string, p validations.URLPolicy, timeout time.Duration) Rule {
+	return Rule{
+		Name:  name,
+		Kinds: stringKinds,
+		Func: func(value reflect.Value, _ string) error {
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			return p.ValidateResolved(ctx, value.String())
+		},
+	}
+}
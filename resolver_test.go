@@ -0,0 +1,95 @@
+package validator
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/solrac97gr/validator/validations"
+)
+
+// resolverFunc is a validations.Resolver calling a function.
+type resolverFunc func(ctx context.Context, host string) ([]string, error)
+
+func (f resolverFunc) LookupHost(ctx context.Context, host string) ([]string, error) {
+	return f(ctx, host)
+}
+
+var hosts = validations.FakeResolver{
+	"example.com":   {"93.184.215.14"},
+	"internal.test": {"10.0.0.1"},
+}
+
+type site struct {
+	Domain  string `validate:"fqdn_exists"`
+	Webhook string `validate:"webhook_url"`
+}
+
+func (s *site) Validate(...interface{}) error { return nil }
+
+func newResolvingValidator(t *testing.T, r validations.Resolver, timeout time.Duration) *ValidatorImpl {
+	t.Helper()
+	v := NewValidator()
+	policy := validations.URLPolicy{Schemes: []string{"https"}, Resolver: r}
+	for _, rule := range []Rule{FQDNExistsRule(r, timeout), URLPolicyRule("webhook_url", policy, timeout)} {
+		if err := v.RegisterRule(rule); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return v
+}
+
+func TestResolvingRules(t *testing.T) {
+	v := newResolvingValidator(t, hosts, time.Second)
+	tests := []struct {
+		site site
+		want map[string]error
+	}{
+		{site{"example.com", "https://example.com/hook"}, nil},
+		{site{"missing.example.com", "https://example.com/hook"}, map[string]error{"Domain": validations.ErrFQDNNotFound}},
+		{site{"example.com", "https://internal.test/hook"}, map[string]error{"Webhook": validations.ErrNotPublicIP}},
+		{site{"-bad-", "http://example.com/hook"}, map[string]error{
+			"Domain":  validations.ErrInvalidFQDN,
+			"Webhook": validations.ErrURLScheme,
+		}},
+	}
+	for _, tt := range tests {
+		err := v.Struct(&tt.site)
+		var errs ValidationErrors
+		if len(tt.want) == 0 {
+			if err != nil {
+				t.Errorf("Struct(%+v) = %v, want nil", tt.site, err)
+			}
+			continue
+		}
+		if !errors.As(err, &errs) || len(errs) != len(tt.want) {
+			t.Errorf("Struct(%+v) = %v, want %d errors", tt.site, err, len(tt.want))
+			continue
+		}
+		for _, fe := range errs {
+			if !errors.Is(fe, tt.want[fe.Field]) {
+				t.Errorf("Struct(%+v): %s: %v, want %v", tt.site, fe.Field, fe.Err, tt.want[fe.Field])
+			}
+		}
+	}
+}
+
+func TestResolvingRulesTimeout(t *testing.T) {
+	// The resolver blocks until the rule gives up.
+	r := resolverFunc(func(ctx context.Context, host string) ([]string, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	v := newResolvingValidator(t, r, 10*time.Millisecond)
+	err := v.Struct(&site{"example.com", "https://example.com/hook"})
+	var errs ValidationErrors
+	if !errors.As(err, &errs) || len(errs) != 2 {
+		t.Fatalf("Struct() = %v, want 2 errors", err)
+	}
+	for _, fe := range errs {
+		if !errors.Is(fe, context.DeadlineExceeded) {
+			t.Errorf("%s: %v, want %v", fe.Field, fe.Err, context.DeadlineExceeded)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package rulefile
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io/fs"
+	"time"
+
+	"github.com/solrac97gr/validator"
+)
+
+// ErrInvalidInterval is returned by Watch when the interval is not positive.
+var ErrInvalidInterval = errors.New("watch interval must be positive")
+
+// Target receives the rules loaded from a file. It is implemented by
+// *validator.ValidatorImpl.
+type Target interface {
+	Registry
+	SetRuleSet(rs validator.RuleSet)
+}
+
+// Loader loads a rule file from a file system into a validator, and
+// reloads it when its content changes.
+type Loader struct {
+	fsys   fs.FS
+	name   string
+	target Target
+	sum    []byte
+
+	// OnError is called by Watch when a reload fails. The rules loaded
+	// before stay in effect.
+	OnError func(error)
+	// OnReload is called by Watch after new rules have been loaded.
+	OnReload func()
+	// Types lists the structs the rules are written for, as for Parse.
+	// Files naming other types or fields fail to load.
+	Types []interface{}
+	// Unchecked loads files without checking type and field names, as
+	// ParseUnchecked does, when Types is empty. Otherwise loading fails
+	// with ErrNoTypes.
+	Unchecked bool
+}
+
+// NewLoader returns a loader for the named file of fsys.
+func NewLoader(fsys fs.FS, name string, target Target) *Loader {
+	return &Loader{fsys: fsys, name: name, target: target}
+}
+
+// Load reads the file and, if its content changed since the last
+// successful load, parses it and hands the rules to the target. It reports
+// whether new rules were loaded.
+func (l *Loader) Load() (bool, error) {
+	data, err := fs.ReadFile(l.fsys, l.name)
+	if err != nil {
+		return false, err
+	}
+	sum := sha256.Sum256(data)
+	if bytes.Equal(sum[:], l.sum) {
+		return false, nil
+	}
+	var rs validator.RuleSet
+	if len(l.Types) == 0 && l.Unchecked {
+		rs, err = ParseUnchecked(data, l.target)
+	} else {
+		rs, err = Parse(data, l.target, l.Types...)
+	}
+	if err != nil {
+		return false, err
+	}
+	l.target.SetRuleSet(rs)
+	l.sum = sum[:]
+	return true, nil
+}
+
+// Watch checks the file every interval until ctx is done, reloading it
+// when it changes, and returns the error of ctx. Load must not be called
+// while Watch is running.
+func (l *Loader) Watch(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return fmt.Errorf("%w: %v", ErrInvalidInterval, interval)
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			loaded, err := l.Load()
+			switch {
+			case err != nil && l.OnError != nil:
+				l.OnError(err)
+			case loaded && l.OnReload != nil:
+				l.OnReload()
+			}
+		}
+	}
+}
+
+// Load parses the named file of fsys, written for types as for Parse, and
+// loads its rules into target.
+func Load(fsys fs.FS, name string, target Target, types ...interface{}) error {
+	l := NewLoader(fsys, name, target)
+	l.Types = types
+	_, err := l.Load()
+	return err
+}
@@ -0,0 +1,201 @@
+package rulefile
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/solrac97gr/validator"
+)
+
+// memFS is a file system whose files can be replaced while it is read.
+type memFS struct {
+	mu    sync.Mutex
+	files fstest.MapFS
+}
+
+func newMemFS(name, content string) *memFS {
+	return &memFS{files: fstest.MapFS{name: {Data: []byte(content)}}}
+}
+
+func (m *memFS) Open(name string) (fs.File, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.files.Open(name)
+}
+
+func (m *memFS) write(name, content string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.files[name] = &fstest.MapFile{Data: []byte(content)}
+}
+
+const (
+	shortNames = "version: 1\ntypes:\n  user:\n    Name: required,max=3\n"
+	longNames  = "version: 1\ntypes:\n  user:\n    Name: required,min=4\n"
+)
+
+func TestLoader(t *testing.T) {
+	fsys := newMemFS("rules.yaml", shortNames)
+	val := validator.NewValidator()
+	l := NewLoader(fsys, "rules.yaml", val)
+	l.Types = []interface{}{user{}}
+
+	if loaded, err := l.Load(); !loaded || err != nil {
+		t.Fatalf("Load() = %v, %v, want true, nil", loaded, err)
+	}
+	if err := val.Struct(&user{Name: "Ada"}); err != nil {
+		t.Errorf("Struct() = %v, want nil", err)
+	}
+	if loaded, err := l.Load(); loaded || err != nil {
+		t.Errorf("Load() of an unchanged file = %v, %v, want false, nil", loaded, err)
+	}
+
+	fsys.write("rules.yaml", longNames)
+	if loaded, err := l.Load(); !loaded || err != nil {
+		t.Fatalf("Load() = %v, %v, want true, nil", loaded, err)
+	}
+	if err := val.Struct(&user{Name: "Ada"}); !failsWith(err, validator.ErrMin) {
+		t.Errorf("Struct() = %v, want %v", err, validator.ErrMin)
+	}
+
+	// A broken file leaves the rules in effect.
+	fsys.write("rules.yaml", "version: 1\ntypes:\n  user:\n    Nmae: required\n")
+	if _, err := l.Load(); !errors.Is(err, validator.ErrUnknownField) {
+		t.Errorf("Load() = %v, want %v", err, validator.ErrUnknownField)
+	}
+	if err := val.Struct(&user{Name: "Ada"}); !failsWith(err, validator.ErrMin) {
+		t.Errorf("Struct() after a failed load = %v, want %v", err, validator.ErrMin)
+	}
+
+	fsys.write("rules.yaml", "version: 1\ntypes:\n  Config:\n    port: required\n")
+	l = NewLoader(fsys, "rules.yaml", validator.NewValidator())
+	if _, err := l.Load(); !errors.Is(err, ErrNoTypes) {
+		t.Errorf("Load() without types = %v, want %v", err, ErrNoTypes)
+	}
+	l.Unchecked = true
+	if loaded, err := l.Load(); !loaded || err != nil {
+		t.Errorf("Load() unchecked = %v, %v, want true, nil", loaded, err)
+	}
+
+	if err := Load(fsys, "missing.yaml", val, user{}); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Load() of a missing file = %v, want %v", err, fs.ErrNotExist)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	fsys := newMemFS("rules.yaml", shortNames)
+	val := validator.NewValidator()
+	l := NewLoader(fsys, "rules.yaml", val)
+	l.Types = []interface{}{user{}}
+	reloads := make(chan struct{}, 10)
+	failures := make(chan error, 10)
+	l.OnReload = func() { reloads <- struct{}{} }
+	l.OnError = func(err error) { failures <- err }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- l.Watch(ctx, time.Millisecond) }()
+
+	wait := func(what string) {
+		t.Helper()
+		select {
+		case <-reloads:
+		case err := <-failures:
+			t.Fatalf("%s: %v", what, err)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: no reload", what)
+		}
+	}
+	wait("first load")
+	if err := val.Struct(&user{Name: "Ada"}); err != nil {
+		t.Errorf("Struct() = %v, want nil", err)
+	}
+
+	fsys.write("rules.yaml", longNames)
+	wait("reload")
+	if err := val.Struct(&user{Name: "Ada"}); !failsWith(err, validator.ErrMin) {
+		t.Errorf("Struct() = %v, want %v", err, validator.ErrMin)
+	}
+
+	fsys.write("rules.yaml", "version: 1\ntypes:\n  user:\n    Name: requird\n")
+	select {
+	case err := <-failures:
+		if !errors.Is(err, validator.ErrUnknownRule) {
+			t.Errorf("OnError(%v), want %v", err, validator.ErrUnknownRule)
+		}
+	case <-reloads:
+		t.Fatal("a broken file was loaded")
+	case <-time.After(5 * time.Second):
+		t.Fatal("no error reported")
+	}
+	if err := val.Struct(&user{Name: "Ada"}); !failsWith(err, validator.ErrMin) {
+		t.Errorf("Struct() after a failed reload = %v, want %v", err, validator.ErrMin)
+	}
+
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Watch() = %v, want %v", err, context.Canceled)
+	}
+
+	if err := l.Watch(context.Background(), 0); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("Watch(0) = %v, want %v", err, ErrInvalidInterval)
+	}
+}
+
+// TestReloadWhileValidating swaps rules while other goroutines validate,
+// checking that every validation sees one rule set or the other.
+func TestReloadWhileValidating(t *testing.T) {
+	fsys := newMemFS("rules.yaml", shortNames)
+	val := validator.NewValidator()
+	l := NewLoader(fsys, "rules.yaml", val)
+	l.Types = []interface{}{user{}}
+	if _, err := l.Load(); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				// "Ada" only fails min=4 and "Grace" only fails max=3.
+				err := val.Struct(&user{Name: "Ada"})
+				if err != nil && !failsWith(err, validator.ErrMin) {
+					t.Errorf("Struct(Ada) = %v", err)
+					return
+				}
+				err = val.Struct(&user{Name: "Grace"})
+				if err != nil && !failsWith(err, validator.ErrMax) {
+					t.Errorf("Struct(Grace) = %v", err)
+					return
+				}
+			}
+		}()
+	}
+	for i := 0; i < 200; i++ {
+		content := shortNames
+		if i%2 == 0 {
+			content = longNames
+		}
+		fsys.write("rules.yaml", content)
+		if _, err := l.Load(); err != nil {
+			t.Error(err)
+			break
+		}
+	}
+	cancel()
+	wg.Wait()
+}
+
+// failsWith reports whether err holds a single field error wrapping target.
+func failsWith(err, target error) bool {
+	var errs validator.ValidationErrors
+	return errors.As(err, &errs) && len(errs) == 1 && errors.Is(errs[0], target)
+}
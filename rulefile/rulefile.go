@@ -0,0 +1,247 @@
+// Package rulefile loads validation rules from YAML or JSON files, so
+// limits can be tuned without rebuilding the program.
+//
+// A rule file maps type names to field names to rules, written either as a
+// validate tag or as a list:
+//
+//	version: 1
+//	types:
+//	  User:
+//	    Name: required,max=40
+//	    Age: [required, min=18]
+//
+// Since JSON is a subset of YAML, the same file can be written in JSON.
+// Rules override the default group of the validate tag, so group sections
+// such as "create:required" are rejected. A field with no rules is
+// rejected as well, since it would silently disable its validate tag:
+// write "-" to do so on purpose.
+package rulefile
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/solrac97gr/validator"
+	"gopkg.in/yaml.v3"
+)
+
+// Version is the rule file format version understood by this package.
+const Version = 1
+
+var (
+	// ErrInvalidFile is returned when a rule file does not follow the expected structure.
+	ErrInvalidFile = errors.New("invalid rule file")
+	// ErrUnknownType is returned when a rule file names a type that is not among the expected ones.
+	ErrUnknownType = errors.New("unknown type")
+	// ErrNoTypes is returned by Parse when it is given no types to check the names of the file against.
+	ErrNoTypes = errors.New("no types to check the rule file against")
+)
+
+// Registry looks up rules by name. It is implemented by
+// *validator.ValidatorImpl.
+type Registry interface {
+	Rule(name string) (validator.Rule, bool)
+}
+
+// Error describes a problem found at a given location of a rule file.
+type Error struct {
+	// Line is the line of the file the problem was found at.
+	Line int
+	// Path is the location of the problem, e.g. "types.User.Name".
+	Path string
+	// Err is the problem.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return fmt.Sprintf("line %d: %s: %v", e.Line, e.Path, e.Err)
+}
+
+// Unwrap returns the problem.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Parse parses a rule file, checking its structure, that every rule is
+// known to reg with a valid parameter, and that the file only names the
+// given types and their exported fields, so that typos do not go
+// unnoticed. types are values of the structs the rules are written for,
+// or pointers to them; Parse fails with ErrNoTypes without any. All
+// problems are reported at once.
+func Parse(data []byte, reg Registry, types ...interface{}) (validator.RuleSet, error) {
+	if len(types) == 0 {
+		return nil, ErrNoTypes
+	}
+	known := make(map[string]reflect.Type, 2*len(types))
+	for _, v := range types {
+		t := reflect.TypeOf(v)
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		known[t.Name()] = t
+		known[t.PkgPath()+"."+t.Name()] = t
+	}
+	return parse(data, &parser{reg: reg, known: known})
+}
+
+// ParseUnchecked parses a rule file like Parse, without checking type and
+// field names, for rules applied to values that are not structs, such as
+// maps given to Map.
+func ParseUnchecked(data []byte, reg Registry) (validator.RuleSet, error) {
+	return parse(data, &parser{reg: reg})
+}
+
+// parse parses a rule file with p.
+func parse(data []byte, p *parser) (validator.RuleSet, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFile, err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("%w: empty file", ErrInvalidFile)
+	}
+
+	rs := p.file(doc.Content[0])
+	if len(p.errs) > 0 {
+		return nil, errors.Join(p.errs...)
+	}
+	return rs, nil
+}
+
+// parser collects the problems found while parsing a rule file.
+type parser struct {
+	reg   Registry
+	known map[string]reflect.Type // by bare and qualified name; nil to skip the checks
+	errs  []error
+}
+
+// fail records a problem found at node n.
+func (p *parser) fail(n *yaml.Node, path string, err error) {
+	p.errs = append(p.errs, &Error{Line: n.Line, Path: path, Err: err})
+}
+
+// invalid records a structural problem found at node n.
+func (p *parser) invalid(n *yaml.Node, path, msg string) {
+	p.fail(n, path, fmt.Errorf("%w: %s", ErrInvalidFile, msg))
+}
+
+// file parses the top-level mapping.
+func (p *parser) file(n *yaml.Node) validator.RuleSet {
+	if n.Kind != yaml.MappingNode {
+		p.invalid(n, "$", "expected a mapping")
+		return nil
+	}
+	rs := validator.RuleSet{}
+	seenVersion := false
+	for i := 0; i < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		switch key.Value {
+		case "version":
+			seenVersion = true
+			var version int
+			if value.Decode(&version) != nil || version != Version {
+				p.invalid(value, "version", fmt.Sprintf("unsupported version %q", value.Value))
+			}
+		case "types":
+			p.types(value, rs)
+		default:
+			p.invalid(key, key.Value, "unknown key")
+		}
+	}
+	if !seenVersion {
+		p.invalid(n, "version", "missing version")
+	}
+	return rs
+}
+
+// types parses the mapping of type names to fields.
+func (p *parser) types(n *yaml.Node, rs validator.RuleSet) {
+	if n.Kind != yaml.MappingNode {
+		p.invalid(n, "types", "expected a mapping of type names")
+		return
+	}
+	for i := 0; i < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		path := "types." + key.Value
+		if value.Kind != yaml.MappingNode {
+			p.invalid(value, path, "expected a mapping of field names")
+			continue
+		}
+		t, known := p.known[key.Value]
+		if p.known != nil && !known {
+			p.fail(key, path, fmt.Errorf("%w %q", ErrUnknownType, key.Value))
+		}
+		fields := make(map[string][]validator.TagRule, len(value.Content)/2)
+		for j := 0; j < len(value.Content); j += 2 {
+			fkey, fvalue := value.Content[j], value.Content[j+1]
+			if known && !hasField(t, fkey.Value) {
+				p.fail(fkey, path+"."+fkey.Value, fmt.Errorf("%w %q of %s", validator.ErrUnknownField, fkey.Value, t.Name()))
+			}
+			fields[fkey.Value] = p.rules(fvalue, path+"."+fkey.Value)
+		}
+		rs[key.Value] = fields
+	}
+}
+
+// rules parses the rules of a field, given as a tag string or a list.
+func (p *parser) rules(n *yaml.Node, path string) []validator.TagRule {
+	var tag string
+	switch n.Kind {
+	case yaml.ScalarNode:
+		tag = n.Value
+	case yaml.SequenceNode:
+		parts := make([]string, 0, len(n.Content))
+		for _, item := range n.Content {
+			if item.Kind != yaml.ScalarNode {
+				p.invalid(item, path, "expected a rule")
+				continue
+			}
+			parts = append(parts, item.Value)
+		}
+		tag = strings.Join(parts, ",")
+	default:
+		p.invalid(n, path, "expected a rule string or a list of rules")
+		return nil
+	}
+	if strings.TrimSpace(tag) == "-" {
+		return nil
+	}
+
+	groups := validator.ParseTagGroups(tag)
+	names := make([]string, 0, len(groups))
+	for group := range groups {
+		if group != validator.DefaultGroup {
+			names = append(names, group)
+		}
+	}
+	sort.Strings(names)
+	for _, group := range names {
+		p.invalid(n, path, fmt.Sprintf("group section %q: rules override the default group only", group))
+	}
+	rules := groups[validator.DefaultGroup]
+	if len(rules) == 0 && len(names) == 0 {
+		p.invalid(n, path, `no rules: write "-" to remove the rules of the field`)
+	}
+	for _, r := range rules {
+		rule, ok := p.reg.Rule(r.Name)
+		if !ok {
+			p.fail(n, path, fmt.Errorf("%w %q", validator.ErrUnknownRule, r.Name))
+			continue
+		}
+		if err := rule.CheckParam(r.Param); err != nil {
+			p.fail(n, path, err)
+		}
+	}
+	return rules
+}
+
+// hasField reports whether struct type t has an exported field named
+// name, which rules can be attached to.
+func hasField(t reflect.Type, name string) bool {
+	sf, ok := t.FieldByName(name)
+	return ok && sf.IsExported() && len(sf.Index) == 1
+}
@@ -0,0 +1,143 @@
+package rulefile
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/solrac97gr/validator"
+)
+
+type user struct {
+	Name  string `validate:"required"`
+	Age   int    `validate:"gte=0"`
+	email string
+}
+
+func (u *user) Validate(...interface{}) error { return nil }
+
+type team struct {
+	Name string
+}
+
+func TestParse(t *testing.T) {
+	data := `version: 1
+types:
+  user:
+    Name: required,max=40
+    Age: [required, "min=18"]
+  github.com/solrac97gr/validator/rulefile.team:
+    Name: "-"
+`
+	rs, err := Parse([]byte(data), validator.NewValidator(), user{}, &team{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := validator.RuleSet{
+		"user": {
+			"Name": {{Name: "required"}, {Name: "max", Param: "40"}},
+			"Age":  {{Name: "required"}, {Name: "min", Param: "18"}},
+		},
+		"github.com/solrac97gr/validator/rulefile.team": {"Name": nil},
+	}
+	if !equalRuleSets(rs, want) {
+		t.Errorf("Parse() = %v, want %v", rs, want)
+	}
+
+	// JSON is YAML.
+	rs, err = Parse([]byte(`{"version": 1, "types": {"user": {"Name": ["required"]}}}`), validator.NewValidator(), user{})
+	if err != nil || len(rs["user"]["Name"]) != 1 {
+		t.Errorf("Parse(JSON) = %v, %v", rs, err)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []error // one per problem, in order
+		line int     // of the first problem, if known
+	}{
+		{"syntax", "version: [1", []error{ErrInvalidFile}, 0},
+		{"empty file", "", []error{ErrInvalidFile}, 0},
+		{"not a mapping", "- 1", []error{ErrInvalidFile}, 1},
+		{"missing version", "types: {}", []error{ErrInvalidFile}, 1},
+		{"unsupported version", "version: 2\ntypes: {}", []error{ErrInvalidFile}, 1},
+		{"unknown key", "version: 1\ntype: {}", []error{ErrInvalidFile}, 2},
+		{"types list", "version: 1\ntypes: [user]", []error{ErrInvalidFile}, 2},
+		{"fields list", "version: 1\ntypes:\n  user: [Name]", []error{ErrInvalidFile}, 3},
+		{"nested rules", "version: 1\ntypes:\n  user:\n    Name: {required: true}", []error{ErrInvalidFile}, 4},
+		{"unknown rule", "version: 1\ntypes:\n  user:\n    Name: requird", []error{validator.ErrUnknownRule}, 4},
+		{"bad parameter", "version: 1\ntypes:\n  user:\n    Age: min=abc", []error{validator.ErrInvalidParam}, 4},
+		{"group section", "version: 1\ntypes:\n  user:\n    Name: create:required", []error{ErrInvalidFile}, 4},
+		{"unknown type", "version: 1\ntypes:\n  usr:\n    Name: required", []error{ErrUnknownType}, 3},
+		{"unknown field", "version: 1\ntypes:\n  user:\n    Nmae: required", []error{validator.ErrUnknownField}, 4},
+		{"unexported field", "version: 1\ntypes:\n  user:\n    email: required", []error{validator.ErrUnknownField}, 4},
+		{"empty rules", "version: 1\ntypes:\n  user:\n    Name: \"\"", []error{ErrInvalidFile}, 4},
+		{"null rules", "version: 1\ntypes:\n  user:\n    Name:", []error{ErrInvalidFile}, 4},
+		{"empty list", "version: 1\ntypes:\n  user:\n    Name: []", []error{ErrInvalidFile}, 4},
+		{"only commas", "version: 1\ntypes:\n  user:\n    Name: \",,\"", []error{ErrInvalidFile}, 4},
+		{"all problems", "version: 1\ntypes:\n  user:\n    Name: requird\n    Age: min=abc\n  usr: {}", []error{validator.ErrUnknownRule, validator.ErrInvalidParam, ErrUnknownType}, 4},
+	}
+	for _, tt := range tests {
+		_, err := Parse([]byte(tt.data), validator.NewValidator(), user{})
+		if err == nil {
+			t.Errorf("%s: Parse() = nil, want %v", tt.name, tt.want)
+			continue
+		}
+		errs := []error{err}
+		if joined, ok := err.(interface{ Unwrap() []error }); ok {
+			errs = joined.Unwrap()
+		}
+		if len(errs) != len(tt.want) {
+			t.Errorf("%s: Parse() = %v, want %d problems", tt.name, err, len(tt.want))
+			continue
+		}
+		for i, want := range tt.want {
+			if !errors.Is(errs[i], want) {
+				t.Errorf("%s: problem %d = %v, want %v", tt.name, i, errs[i], want)
+			}
+		}
+		var fe *Error
+		if tt.line > 0 && (!errors.As(errs[0], &fe) || fe.Line != tt.line) {
+			t.Errorf("%s: Parse() = %v, want line %d", tt.name, err, tt.line)
+		}
+	}
+}
+
+func TestParseUnchecked(t *testing.T) {
+	data := []byte("version: 1\ntypes:\n  Config:\n    port: required,port\n")
+	if _, err := Parse(data, validator.NewValidator()); !errors.Is(err, ErrNoTypes) {
+		t.Errorf("Parse() without types = %v, want %v", err, ErrNoTypes)
+	}
+	rs, err := ParseUnchecked(data, validator.NewValidator())
+	if err != nil || len(rs["Config"]["port"]) != 2 {
+		t.Errorf("ParseUnchecked() = %v, %v", rs, err)
+	}
+	// Rules are still checked.
+	if _, err := ParseUnchecked([]byte("version: 1\ntypes:\n  Config:\n    port: prot\n"), validator.NewValidator()); !errors.Is(err, validator.ErrUnknownRule) {
+		t.Errorf("ParseUnchecked() = %v, want %v", err, validator.ErrUnknownRule)
+	}
+}
+
+func equalRuleSets(a, b validator.RuleSet) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for typ, fields := range a {
+		if len(fields) != len(b[typ]) {
+			return false
+		}
+		for field, rules := range fields {
+			other, ok := b[typ][field]
+			if !ok || len(rules) != len(other) {
+				return false
+			}
+			for i := range rules {
+				if rules[i] != other[i] {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
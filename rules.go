@@ -0,0 +1,138 @@
+package validator
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+)
+
+var (
+	// ErrRequired is returned when a required value is missing.
+	ErrRequired = errors.New("value is required")
+	// ErrUnknownRule is returned when a tag references a rule that is not registered.
+	ErrUnknownRule = errors.New("unknown validation rule")
+	// ErrInvalidParam is returned when a rule parameter cannot be parsed.
+	ErrInvalidParam = errors.New("invalid rule parameter")
+	// ErrNotApplicable is returned when a rule is applied to a value of the wrong kind.
+	ErrNotApplicable = errors.New("rule cannot be applied to this type")
+	// ErrInvalidRule is returned when registering a rule without a name or function.
+	ErrInvalidRule = errors.New("invalid rule definition")
+)
+
+// RuleFunc validates value against the rule parameter. param is empty for
+// rules that take no parameter. value has already been dereferenced and is
+// never a nil pointer, except for the "required" rule.
+type RuleFunc func(value reflect.Value, param string) error
+
+// ParamKind describes the parameter a rule expects.
+type ParamKind int
+
+const (
+	// ParamNone is used by rules that take no parameter.
+	ParamNone ParamKind = iota
+	// ParamNumber is used by rules that take a numeric parameter.
+	ParamNumber
+	// ParamString is used by rules that take a free-form string parameter.
+	ParamString
+	// ParamList is used by rules that take a space separated list of values.
+	ParamList
+	// ParamField is used by cross-field rules that take the dotted path of
+	// another field of the struct, such as "Password" or "Range.Start".
+	ParamField
+)
+
+// Rule describes a named validation rule.
+type Rule struct {
+	// Name is the name used to reference the rule in tags.
+	Name string
+	// Func performs the validation.
+	Func RuleFunc
+	// Kinds lists the kinds the rule can be applied to. Empty means any kind.
+	Kinds []reflect.Kind
+	// Param describes the parameter the rule expects. It is only used by
+	// CheckParam, so tooling can report bad parameters before runtime.
+	Param ParamKind
+}
+
+// AppliesTo reports whether the rule can be applied to values of kind k.
+func (r Rule) AppliesTo(k reflect.Kind) bool {
+	if len(r.Kinds) == 0 {
+		return true
+	}
+	for _, kind := range r.Kinds {
+		if kind == k {
+			return true
+		}
+	}
+	return false
+}
+
+// BuiltinRules returns the rules every validator knows about, sorted by name.
+func BuiltinRules() []Rule {
+	rules := make([]Rule, 0, len(builtinRules))
+	for _, r := range builtinRules {
+		rules = append(rules, r)
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Name < rules[j].Name })
+	return rules
+}
+
+// RegisterRule adds a custom rule to the validator, replacing any rule with
+// the same name.
+func (v *ValidatorImpl) RegisterRule(r Rule) error {
+	if r.Name == "" || r.Func == nil {
+		return ErrInvalidRule
+	}
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if v.rules == nil {
+		v.rules = make(map[string]Rule)
+	}
+	v.rules[r.Name] = r
+	return nil
+}
+
+// Rule returns the rule registered under name.
+func (v *ValidatorImpl) Rule(name string) (Rule, bool) {
+	v.mu.RLock()
+	r, ok := v.rules[name]
+	v.mu.RUnlock()
+	if ok {
+		return r, true
+	}
+	r, ok = builtinRules[name]
+	return r, ok
+}
+
+// apply runs the named rule against value.
+func (v *ValidatorImpl) apply(name, param string, value reflect.Value) error {
+	r, ok := v.Rule(name)
+	if !ok {
+		return fmt.Errorf("%w %q", ErrUnknownRule, name)
+	}
+	if value.IsValid() && !r.AppliesTo(value.Kind()) {
+		return fmt.Errorf("%w: %s on %s", ErrNotApplicable, name, value.Kind())
+	}
+	return r.Func(value, param)
+}
+
+// CheckParam reports whether param is a valid parameter for the rule.
+func (r Rule) CheckParam(param string) error {
+	switch r.Param {
+	case ParamNone:
+		if param != "" {
+			return fmt.Errorf("%w: %s takes no parameter", ErrInvalidParam, r.Name)
+		}
+	case ParamNumber:
+		if _, err := strconv.ParseFloat(param, 64); err != nil {
+			return fmt.Errorf("%w: %s expects a number, got %q", ErrInvalidParam, r.Name, param)
+		}
+	case ParamString, ParamList, ParamField:
+		if param == "" {
+			return fmt.Errorf("%w: %s expects a parameter", ErrInvalidParam, r.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+package validator
+
+import (
+	"reflect"
+	"sync"
+)
+
+// RuleSet holds rules defined outside of struct tags. It maps a type name
+// to a field name to the rules of that field. Type names are either the
+// bare name ("User") or qualified with the package path
+// ("github.com/acme/api.User"); the qualified name wins when both exist.
+type RuleSet map[string]map[string][]TagRule
+
+// ruleSetState is a rule set with the types it was found to override.
+type ruleSetState struct {
+	rules      RuleSet
+	overridden sync.Map // reflect.Type -> bool
+}
+
+// SetRuleSet replaces the rules loaded with a previous call. Rules in the
+// set override the default group of the validate tag of the fields they
+// name. Structs with a generated Validate method are validated by
+// reflection while the set has rules for them or for a struct nested in
+// them, since generated code cannot see the set. It is safe to call while
+// other goroutines are validating.
+func (v *ValidatorImpl) SetRuleSet(rs RuleSet) {
+	v.ruleSet.Store(&ruleSetState{rules: rs})
+}
+
+// FieldRules returns the rules of the default group checked for the named
+// field of struct type t: the ones from the rule set if any, otherwise the
+// validate tag.
+func (v *ValidatorImpl) FieldRules(t reflect.Type, field string) []TagRule {
+	if rules, ok := v.ruleSetRules(t, field); ok {
+		return rules
+	}
+	sf, ok := t.FieldByName(field)
+	if !ok {
+		return nil
+	}
+	return ParseTag(sf.Tag.Get(TagName))
+}
+
+// ruleSetRules returns the rules the rule set defines for a field.
+func (v *ValidatorImpl) ruleSetRules(t reflect.Type, field string) ([]TagRule, bool) {
+	state := v.ruleSet.Load()
+	if state == nil || len(state.rules) == 0 {
+		return nil, false
+	}
+	for _, name := range typeNames(t) {
+		if rules, ok := state.rules[name][field]; ok {
+			return rules, true
+		}
+	}
+	return nil, false
+}
+
+// overridden reports whether the rule set has rules for the struct type
+// of s or for a struct type reachable from its fields.
+func (v *ValidatorImpl) overridden(s interface{}) bool {
+	state := v.ruleSet.Load()
+	if state == nil || len(state.rules) == 0 {
+		return false
+	}
+	t := reflect.TypeOf(s)
+	if cached, ok := state.overridden.Load(t); ok {
+		return cached.(bool)
+	}
+	found := state.rules.reaches(t, make(map[reflect.Type]bool))
+	state.overridden.Store(t, found)
+	return found
+}
+
+// typeNames returns the names a rule set may use for t, qualified first.
+func typeNames(t reflect.Type) []string {
+	return []string{t.PkgPath() + "." + t.Name(), t.Name()}
+}
+
+// reaches reports whether rs has rules for t or for a struct type reachable
+// from it, skipping the types in seen.
+func (rs RuleSet) reaches(t reflect.Type, seen map[reflect.Type]bool) bool {
+	for t.Kind() == reflect.Ptr || t.Kind() == reflect.Slice || t.Kind() == reflect.Array || t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == timeType || seen[t] {
+		return false
+	}
+	seen[t] = true
+	if t.Name() != "" {
+		for _, name := range typeNames(t) {
+			if len(rs[name]) > 0 {
+				return true
+			}
+		}
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if sf := t.Field(i); (sf.IsExported() || sf.Anonymous) && rs.reaches(sf.Type, seen) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,314 @@
+package stream
+
+import (
+	"encoding"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/solrac97gr/validator"
+)
+
+// CSVTagName is the struct tag naming the CSV column of a field.
+const CSVTagName = "csv"
+
+// ErrNoHeader is returned when a CSV input is empty.
+var ErrNoHeader = errors.New("missing CSV header")
+
+// CSV reads comma separated values into records of type T. The first line
+// is a header naming the column of each field: a column is mapped to the
+// field whose csv tag, json tag or name matches it, ignoring case. Dotted
+// names such as "address.city" map to the fields of nested structs. Fields
+// tagged csv:"-" and other columns are ignored.
+//
+// Fields can be strings, booleans, numbers, time.Time in RFC 3339 format,
+// implementations of encoding.TextUnmarshaler, or pointers to those. Empty
+// cells leave fields to their zero value.
+type CSV[T any, PT recordPtr[T]] struct {
+	// Reader is the underlying CSV reader. Its options, such as Comma, can
+	// be changed before the first call to Next.
+	Reader *csv.Reader
+
+	val     validator.Validator
+	columns [][]int        // field index path of each column, nil if unmapped
+	fields  map[string]int // 1-based column of each mapped field, by path
+	rec     T
+	report  Report
+	err     error
+}
+
+// NewCSV returns a reader decoding the rows of r into values of type T and
+// validating them with val.
+func NewCSV[T any, PT recordPtr[T]](r io.Reader, val validator.Validator) *CSV[T, PT] {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	return &CSV[T, PT]{Reader: cr, val: val}
+}
+
+// Next reads the next record. It returns false at the end of the input or
+// when reading fails, which Err reports. Malformed rows are reported as
+// issues of their record and reading goes on.
+func (d *CSV[T, PT]) Next() bool {
+	if d.err != nil {
+		return false
+	}
+	if d.columns == nil {
+		if d.err = d.readHeader(); d.err != nil {
+			return false
+		}
+	}
+	var zero T
+	d.rec = zero
+	row, rep, err := readRow(d.Reader)
+	if err != nil {
+		if err != io.EOF {
+			d.err = err
+		}
+		return false
+	}
+	d.report = rep
+	if row == nil {
+		return true
+	}
+	if d.report.Issues = d.decode(row); d.report.Issues != nil {
+		d.rec = zero
+		return true
+	}
+	d.report.Issues = validate(d.val, PT(&d.rec), func(field string) int {
+		return columnOf(d.fields, field)
+	})
+	return true
+}
+
+// readHeader reads the header and maps its columns to fields.
+func (d *CSV[T, PT]) readHeader() error {
+	header, err := readHeader(d.Reader)
+	if err != nil {
+		return err
+	}
+	t := reflect.TypeOf(d.rec)
+	d.columns = make([][]int, len(header))
+	d.fields = make(map[string]int)
+	for i, name := range header {
+		if index, path, ok := fieldByColumn(t, name); ok {
+			d.columns[i] = index
+			d.fields[path] = i + 1
+		}
+	}
+	return nil
+}
+
+// decode sets the fields of the record from the cells of row.
+func (d *CSV[T, PT]) decode(row []string) Issues {
+	rv := reflect.ValueOf(&d.rec).Elem()
+	var issues Issues
+	for i, cell := range row {
+		if i >= len(d.columns) || d.columns[i] == nil || cell == "" {
+			continue
+		}
+		if err := setCell(fieldByIndex(rv, d.columns[i]), cell); err != nil {
+			name := fieldPath(rv.Type(), d.columns[i])
+			issues = append(issues, Issue{Column: i + 1, Field: name, Err: fmt.Errorf("%w: %s: %v", ErrDecode, name, err)})
+		}
+	}
+	return issues
+}
+
+// Record returns the record read by the last call to Next. It is the zero
+// value when the row could not be decoded.
+func (d *CSV[T, PT]) Record() T {
+	return d.rec
+}
+
+// Report returns the report of the record read by the last call to Next.
+func (d *CSV[T, PT]) Report() Report {
+	return d.report
+}
+
+// Err returns the error that stopped the reader, if any.
+func (d *CSV[T, PT]) Err() error {
+	return d.err
+}
+
+// readHeader reads the names of the columns of cr, without spaces around
+// them or a leading byte order mark.
+func readHeader(cr *csv.Reader) ([]string, error) {
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, ErrNoHeader
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(header))
+	for i, name := range header {
+		if i == 0 {
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		names[i] = strings.TrimSpace(name)
+	}
+	return names, nil
+}
+
+// readRow reads the next row of cr and starts the report of its record.
+// A malformed row is reported as an issue of the record, with a nil row.
+// The error is io.EOF at the end of the input.
+func readRow(cr *csv.Reader) ([]string, Report, error) {
+	row, err := cr.Read()
+	if err != nil {
+		var perr *csv.ParseError
+		if !errors.As(err, &perr) {
+			return nil, Report{}, err
+		}
+		return nil, Report{Line: perr.StartLine, Issues: Issues{{Column: perr.Column, Err: fmt.Errorf("%w: %v", ErrDecode, perr.Err)}}}, nil
+	}
+	line, _ := cr.FieldPos(0)
+	return row, Report{Line: line}, nil
+}
+
+// columnOf returns the column of the field at path, or of the closest
+// field containing it, in columns. It is 0 when there is none.
+func columnOf(columns map[string]int, path string) int {
+	for path != "" {
+		if column, ok := columns[path]; ok {
+			return column
+		}
+		path = path[:max(strings.LastIndexAny(path, ".["), 0)]
+	}
+	return 0
+}
+
+// fieldByColumn returns the index path and the validator path, such as
+// "Address.City", of the exported field of the struct type t mapped to
+// the named column. A dotted name is split into the names of nested
+// fields unless a field matches it whole.
+func fieldByColumn(t reflect.Type, column string) ([]int, string, bool) {
+	t = indirectType(t)
+	if t.Kind() != reflect.Struct {
+		return nil, "", false
+	}
+	if sf, ok := fieldByName(t, column); ok {
+		return sf.Index, sf.Name, true
+	}
+	for i := 0; i < len(column); i++ {
+		if column[i] != '.' {
+			continue
+		}
+		sf, ok := fieldByName(t, column[:i])
+		if !ok {
+			continue
+		}
+		if index, path, ok := fieldByColumn(sf.Type, column[i+1:]); ok {
+			return append(sf.Index, index...), sf.Name + "." + path, true
+		}
+	}
+	return nil, "", false
+}
+
+// fieldByName returns the exported field of the struct type t whose csv
+// tag, json tag or name matches name, ignoring case.
+func fieldByName(t reflect.Type, name string) (reflect.StructField, bool) {
+	for _, tag := range []string{CSVTagName, "json", ""} {
+		for i := 0; i < t.NumField(); i++ {
+			sf := t.Field(i)
+			if !sf.IsExported() || sf.Tag.Get(CSVTagName) == "-" {
+				continue
+			}
+			fieldName := sf.Name
+			if tag != "" {
+				fieldName, _, _ = strings.Cut(sf.Tag.Get(tag), ",")
+			}
+			if fieldName != "" && fieldName != "-" && strings.EqualFold(fieldName, name) {
+				return sf, true
+			}
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// fieldByIndex returns the nested field of the struct v with the given
+// index path, allocating the nil pointers along the way.
+func fieldByIndex(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+// fieldPath returns the validator path of the nested field of the struct
+// type t with the given index path.
+func fieldPath(t reflect.Type, index []int) string {
+	names := make([]string, len(index))
+	for i, x := range index {
+		sf := indirectType(t).Field(x)
+		names[i], t = sf.Name, sf.Type
+	}
+	return strings.Join(names, ".")
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// setCell parses cell into the field f.
+func setCell(f reflect.Value, cell string) error {
+	if f.Kind() == reflect.Ptr {
+		p := reflect.New(f.Type().Elem())
+		if err := setCell(p.Elem(), cell); err != nil {
+			return err
+		}
+		f.Set(p)
+		return nil
+	}
+	if u, ok := f.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		return u.UnmarshalText([]byte(cell))
+	}
+	if f.Type() == timeType {
+		t, err := time.Parse(time.RFC3339, cell)
+		if err != nil {
+			return err
+		}
+		f.Set(reflect.ValueOf(t))
+		return nil
+	}
+	switch f.Kind() {
+	case reflect.String:
+		f.SetString(cell)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return err
+		}
+		f.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(cell, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(cell, 10, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(cell, f.Type().Bits())
+		if err != nil {
+			return err
+		}
+		f.SetFloat(n)
+	default:
+		return fmt.Errorf("unsupported field type %s", f.Type())
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package stream
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/solrac97gr/validator"
+)
+
+type account struct {
+	Email   string    `csv:"e-mail" json:"mail" validate:"required,email"`
+	Name    string    `json:"full_name" validate:"required"`
+	Age     *int      `validate:"omitempty,gte=18"`
+	Active  bool      `csv:"-"`
+	Created time.Time `csv:"created"`
+	Home    *address  `csv:"home"`
+	Work    *address
+	Ignored string `csv:"-" json:"-"`
+}
+
+func (a *account) Validate(...interface{}) error { return nil }
+
+func TestCSV(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []result
+	}{
+		{"header only", "e-mail,full_name\n", nil},
+		{"valid", "e-mail,full_name,age\nada@example.com,Ada,36\ngrace@example.com,Grace,\n", []result{{2, nil}, {3, nil}}},
+		// Headers match csv tags, then json tags, then names, ignoring case.
+		{"header names", "\ufeffE-Mail , FULL_NAME,AGE,active,unknown\nada@example.com,Ada,12,true,x\n", []result{{2, []issue{{3, "Age"}}}}},
+		{"ignored fields", "mail,name,active,ignored\nada@example.com,Ada,x,x\n", []result{{2, nil}}},
+		{"issue columns", "age,full_name,e-mail\n36,,ada\n", []result{{2, []issue{{3, "Email"}, {2, "Name"}}}}},
+		{"decode errors", "e-mail,full_name,age,created\nada@example.com,Ada,x,yesterday\n", []result{{2, []issue{{3, "Age"}, {4, "Created"}}}}},
+		{"malformed row", "e-mail,full_name\n\"ada,Ada\nada@example.com,Ada\n", []result{{2, []issue{{21, ""}}}}},
+		{"short row", "e-mail,full_name,age\nada@example.com,Ada\n", []result{{2, nil}}},
+		{"quoted newline", "e-mail,full_name\nada@example.com,\"Ada\nLovelace\"\ngrace@example.com,\n", []result{{2, nil}, {4, []issue{{2, "Name"}}}}},
+	}
+	val := validator.NewValidator()
+	for _, tt := range tests {
+		r := NewCSV[account](strings.NewReader(tt.input), val)
+		r.Reader.FieldsPerRecord = -1
+		if got := collect(t, r.Next, r.Report); !equalResults(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+		if err := r.Err(); err != nil {
+			t.Errorf("%s: Err() = %v", tt.name, err)
+		}
+	}
+}
+
+func TestCSVNestedFields(t *testing.T) {
+	input := "e-mail,full_name,home.city,HOME.ZIP,work.city,work.zip,home.unknown\n" +
+		"ada@example.com,Ada,London,12345,Paris,75001,x\n" +
+		"grace@example.com,Grace,,123,,,\n"
+	r := NewCSV[account](strings.NewReader(input), validator.NewValidator())
+	got := collect(t, r.Next, r.Report)
+	// The second row has a home address without a city and with a short
+	// zip, and no work address.
+	want := []result{{2, nil}, {3, []issue{{3, "Home.City"}, {4, "Home.Zip"}}}}
+	if !equalResults(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	r = NewCSV[account](strings.NewReader(input), validator.NewValidator())
+	if !r.Next() {
+		t.Fatal(r.Err())
+	}
+	rec := r.Record()
+	if rec.Home == nil || rec.Home.City != "London" || rec.Home.Zip != "12345" || rec.Work == nil || rec.Work.City != "Paris" {
+		t.Errorf("Record() = %+v, want the nested fields set", rec)
+	}
+
+	// Pointers to nested structs stay nil without a value.
+	r = NewCSV[account](strings.NewReader("e-mail,full_name,home.city\nada@example.com,Ada,\n"), validator.NewValidator())
+	if !r.Next() || r.Record().Home != nil {
+		t.Errorf("Record() = %+v, want a nil Home", r.Record())
+	}
+
+	// Decode errors name the nested field.
+	r = NewCSV[account](strings.NewReader("e-mail,full_name,home\nada@example.com,Ada,London\n"), validator.NewValidator())
+	if !r.Next() || len(r.Report().Issues) != 1 || r.Report().Issues[0].Field != "Home" || !errors.Is(r.Report().Err(), ErrDecode) {
+		t.Errorf("Report() = %+v, want a decode error for Home", r.Report())
+	}
+}
+
+func TestCSVErrors(t *testing.T) {
+	r := NewCSV[account](strings.NewReader(""), validator.NewValidator())
+	if r.Next() {
+		t.Error("Next() = true for an empty input")
+	}
+	if err := r.Err(); !errors.Is(err, ErrNoHeader) {
+		t.Errorf("Err() = %v, want %v", err, ErrNoHeader)
+	}
+
+	failure := errors.New("connection reset")
+	r = NewCSV[account](&failingReader{strings.NewReader("e-mail,full_name\nada@example.com,Ada\n"), failure}, validator.NewValidator())
+	if !r.Next() || !r.Report().Valid() {
+		t.Fatalf("first record: %v", r.Report().Err())
+	}
+	if r.Next() || !errors.Is(r.Err(), failure) {
+		t.Errorf("Err() = %v, want %v", r.Err(), failure)
+	}
+
+	// The options of the underlying reader apply.
+	r = NewCSV[account](strings.NewReader("e-mail;full_name\nada@example.com;Ada\n"), validator.NewValidator())
+	r.Reader.Comma = ';'
+	if !r.Next() || !r.Report().Valid() || r.Record().Name != "Ada" {
+		t.Errorf("Record() = %+v, %v", r.Record(), r.Report().Err())
+	}
+}
@@ -0,0 +1,123 @@
+package stream
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/solrac97gr/validator"
+)
+
+// CSVMap reads comma separated values into maps, for records without a
+// struct type, and validates them with the Map method of a validator. The
+// first line is a header naming the key of each column. Dotted names such
+// as "address.city" are keys of nested maps, so that rules reach them by
+// the same path. Empty cells are left out, like missing keys.
+type CSVMap struct {
+	// Reader is the underlying CSV reader. Its options, such as Comma, can
+	// be changed before the first call to Next.
+	Reader *csv.Reader
+	// Cell converts the non-empty cells, which are kept as strings when it
+	// is nil. It can be set before the first call to Next, for instance to
+	// check numbers as numbers.
+	Cell func(cell string) interface{}
+
+	val     *validator.ValidatorImpl
+	rules   map[string]string
+	header  []string
+	columns map[string]int // 1-based column of each key
+	rec     map[string]interface{}
+	report  Report
+	err     error
+}
+
+// NewCSVMap returns a reader decoding the rows of r into maps and
+// validating them against rules, keyed by path as for Map.
+func NewCSVMap(r io.Reader, val *validator.ValidatorImpl, rules map[string]string) *CSVMap {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	return &CSVMap{Reader: cr, val: val, rules: rules}
+}
+
+// Next reads the next record. It returns false at the end of the input or
+// when reading fails, which Err reports. Malformed rows are reported as
+// issues of their record and reading goes on.
+func (d *CSVMap) Next() bool {
+	if d.err != nil {
+		return false
+	}
+	if d.header == nil {
+		if d.header, d.err = readHeader(d.Reader); d.err != nil {
+			return false
+		}
+		d.columns = make(map[string]int, len(d.header))
+		for i, name := range d.header {
+			if _, ok := d.columns[name]; !ok {
+				d.columns[name] = i + 1
+			}
+		}
+	}
+	d.rec = nil
+	row, rep, err := readRow(d.Reader)
+	if err != nil {
+		if err != io.EOF {
+			d.err = err
+		}
+		return false
+	}
+	d.report = rep
+	if row == nil {
+		return true
+	}
+	d.rec = make(map[string]interface{}, len(row))
+	for i, cell := range row {
+		if i >= len(d.header) || cell == "" {
+			continue
+		}
+		var value interface{} = cell
+		if d.Cell != nil {
+			value = d.Cell(cell)
+		}
+		setPath(d.rec, d.header[i], value)
+	}
+	d.report.Issues = issuesOf(d.val.Map(d.rec, d.rules), func(field string) int {
+		return columnOf(d.columns, field)
+	})
+	return true
+}
+
+// Record returns the record read by the last call to Next. It is nil when
+// the row is malformed.
+func (d *CSVMap) Record() map[string]interface{} {
+	return d.rec
+}
+
+// Report returns the report of the record read by the last call to Next.
+func (d *CSVMap) Report() Report {
+	return d.report
+}
+
+// Err returns the error that stopped the reader, if any.
+func (d *CSVMap) Err() error {
+	return d.err
+}
+
+// setPath stores value in rec under a dotted key, in nested maps. The key
+// is kept whole when one of its prefixes already holds another value.
+func setPath(rec map[string]interface{}, key string, value interface{}) {
+	parts := strings.Split(key, ".")
+	m := rec
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := m[part].(map[string]interface{})
+		if !ok {
+			if _, taken := m[part]; taken {
+				rec[key] = value
+				return
+			}
+			next = make(map[string]interface{})
+			m[part] = next
+		}
+		m = next
+	}
+	m[parts[len(parts)-1]] = value
+}
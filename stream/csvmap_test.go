@@ -0,0 +1,99 @@
+package stream
+
+import (
+	"errors"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/solrac97gr/validator"
+)
+
+func TestCSVMap(t *testing.T) {
+	rules := map[string]string{
+		"email":     "required,email",
+		"age":       "omitempty,numeric",
+		"home.city": "omitempty,alpha",
+	}
+	tests := []struct {
+		name  string
+		input string
+		want  []result
+	}{
+		{"header only", "email,age\n", nil},
+		{"valid", "email,age\nada@example.com,36\ngrace@example.com,\n", []result{{2, nil}, {3, nil}}},
+		{"issue columns", "\ufeff age , email,home.city\n3x,,L0ndon\n", []result{{2, []issue{{1, "age"}, {2, "email"}, {3, "home.city"}}}}},
+		{"malformed row", "email,age\n\"ada,36\nada@example.com,36\n", []result{{2, []issue{{20, ""}}}}},
+		{"other columns", "email,nickname\nada@example.com,x\n", []result{{2, nil}}},
+	}
+	val := validator.NewValidator()
+	for _, tt := range tests {
+		r := NewCSVMap(strings.NewReader(tt.input), val, rules)
+		r.Reader.FieldsPerRecord = -1
+		if got := collect(t, r.Next, r.Report); !equalResults(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+		if err := r.Err(); err != nil {
+			t.Errorf("%s: Err() = %v", tt.name, err)
+		}
+	}
+}
+
+func TestCSVMapRecords(t *testing.T) {
+	input := "name,home.city,home.zip,age\nAda,London,,36\n\"Grace\n"
+	r := NewCSVMap(strings.NewReader(input), validator.NewValidator(), nil)
+	r.Cell = func(cell string) interface{} {
+		if n, err := strconv.Atoi(cell); err == nil {
+			return n
+		}
+		return cell
+	}
+	want := []map[string]interface{}{
+		// Empty cells are left out and dotted keys are nested.
+		{"name": "Ada", "home": map[string]interface{}{"city": "London"}, "age": 36},
+		// Malformed rows have no record.
+		nil,
+	}
+	var got []map[string]interface{}
+	for r.Next() {
+		got = append(got, r.Record())
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("records = %v, want %v", got, want)
+	}
+}
+
+func TestCSVMapErrors(t *testing.T) {
+	r := NewCSVMap(strings.NewReader(""), validator.NewValidator(), nil)
+	if r.Next() {
+		t.Fatal("Next() = true on an empty input")
+	}
+	if err := r.Err(); !errors.Is(err, ErrNoHeader) {
+		t.Errorf("Err() = %v, want %v", err, ErrNoHeader)
+	}
+
+	errRead := errors.New("connection reset")
+	r = NewCSVMap(&failingReader{strings.NewReader("email\nada@example.com\n"), errRead}, validator.NewValidator(), nil)
+	for r.Next() {
+	}
+	if err := r.Err(); !errors.Is(err, errRead) {
+		t.Errorf("Err() = %v, want %v", err, errRead)
+	}
+}
+
+func TestSetPath(t *testing.T) {
+	rec := make(map[string]interface{})
+	for _, kv := range [][2]string{{"a.b.c", "1"}, {"a.b.d", "2"}, {"x", "3"}, {"x.y", "4"}} {
+		setPath(rec, kv[0], kv[1])
+	}
+	want := map[string]interface{}{
+		"a": map[string]interface{}{"b": map[string]interface{}{"c": "1", "d": "2"}},
+		"x": "3",
+		// The prefix holds a value, so the key is kept whole.
+		"x.y": "4",
+	}
+	if !reflect.DeepEqual(rec, want) {
+		t.Errorf("setPath() = %v, want %v", rec, want)
+	}
+}
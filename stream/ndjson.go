@@ -0,0 +1,285 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/solrac97gr/validator"
+)
+
+// DefaultMaxLineLength is the default maximum length of NDJSON lines.
+const DefaultMaxLineLength = 1 << 20
+
+// ErrLineTooLong is wrapped by the issues reported for lines longer than
+// the maximum length.
+var ErrLineTooLong = errors.New("line too long")
+
+// NDJSON reads newline delimited JSON, one object per line, into records
+// of type T. Blank lines are skipped. Lines longer than MaxLineLength are
+// skipped without being held in memory and reported with an issue wrapping
+// ErrLineTooLong.
+//
+// The issues of fields that fail validation have the column of their key
+// in the line, or of their element for the elements of arrays. Fields
+// missing from the line have the column of the closest enclosing key, or
+// 0 at the top level.
+type NDJSON[T any, PT recordPtr[T]] struct {
+	// MaxLineLength is the maximum length of a line in bytes, without its
+	// newline. It defaults to DefaultMaxLineLength and can be changed
+	// before the first call to Next.
+	MaxLineLength int
+
+	r      *bufio.Reader
+	val    validator.Validator
+	buf    []byte
+	line   int
+	rec    T
+	report Report
+	err    error
+}
+
+// NewNDJSON returns a reader decoding the lines of r into values of type T
+// and validating them with val.
+func NewNDJSON[T any, PT recordPtr[T]](r io.Reader, val validator.Validator) *NDJSON[T, PT] {
+	return &NDJSON[T, PT]{MaxLineLength: DefaultMaxLineLength, r: bufio.NewReader(r), val: val}
+}
+
+// Next reads the next record. It returns false at the end of the input or
+// when reading fails, which Err reports.
+func (d *NDJSON[T, PT]) Next() bool {
+	for d.err == nil {
+		data, tooLong, err := d.readLine()
+		if err != nil && err != io.EOF {
+			d.err = err
+			return false
+		}
+		if len(data) > 0 || tooLong {
+			d.line++
+		}
+		if tooLong {
+			var zero T
+			d.rec = zero
+			d.report = Report{Line: d.line, Issues: Issues{{Err: fmt.Errorf("%w: more than %d bytes", ErrLineTooLong, d.maxLineLength())}}}
+			return true
+		}
+		if len(bytes.TrimSpace(data)) > 0 {
+			d.decode(data)
+			return true
+		}
+		if err == io.EOF {
+			return false
+		}
+	}
+	return false
+}
+
+// readLine reads the next line, with its newline. When the line is longer
+// than the maximum length, the rest of it is skipped and tooLong is set.
+// The line is only valid until the next call.
+func (d *NDJSON[T, PT]) readLine() (line []byte, tooLong bool, err error) {
+	d.buf = d.buf[:0]
+	for {
+		chunk, err := d.r.ReadSlice('\n')
+		n := len(d.buf) + len(chunk)
+		if err == nil {
+			n-- // the newline
+		}
+		if n > d.maxLineLength() {
+			tooLong = true
+			d.buf = d.buf[:0]
+		}
+		if !tooLong {
+			d.buf = append(d.buf, chunk...)
+		}
+		if err != bufio.ErrBufferFull {
+			return d.buf, tooLong, err
+		}
+	}
+}
+
+// maxLineLength returns MaxLineLength or its default.
+func (d *NDJSON[T, PT]) maxLineLength() int {
+	if d.MaxLineLength <= 0 {
+		return DefaultMaxLineLength
+	}
+	return d.MaxLineLength
+}
+
+// decode decodes and validates a line.
+func (d *NDJSON[T, PT]) decode(data []byte) {
+	var zero T
+	d.rec = zero
+	d.report = Report{Line: d.line}
+	if err := json.Unmarshal(data, PT(&d.rec)); err != nil {
+		issue := Issue{Err: fmt.Errorf("%w: %v", ErrDecode, err)}
+		var syntax *json.SyntaxError
+		var typ *json.UnmarshalTypeError
+		switch {
+		case errors.As(err, &syntax):
+			issue.Column = int(syntax.Offset)
+		case errors.As(err, &typ):
+			issue.Column, issue.Field = int(typ.Offset), typ.Field
+		}
+		d.report.Issues = Issues{issue}
+		d.rec = zero
+		return
+	}
+	var offsets map[string]int
+	d.report.Issues = validate(d.val, PT(&d.rec), func(field string) int {
+		if offsets == nil {
+			offsets = make(map[string]int)
+			walkJSON(json.NewDecoder(bytes.NewReader(data)), data, "", offsets)
+		}
+		path, ok := jsonPath(reflect.TypeOf(d.rec), field)
+		if !ok {
+			return 0
+		}
+		for path != "" {
+			if column, ok := offsets[path]; ok {
+				return column
+			}
+			path = path[:max(strings.LastIndexAny(path, ".["), 0)]
+		}
+		return 0
+	})
+}
+
+// walkJSON reads the next value from dec and records in offsets the 1-based
+// column of the keys of its objects and of the elements of its arrays, by
+// path, such as "address.city" or "tags[1]". Keys are lowercased, as
+// encoding/json matches them without regard to case.
+func walkJSON(dec *json.Decoder, data []byte, path string, offsets map[string]int) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	switch tok {
+	case json.Delim('{'):
+		for dec.More() {
+			column := nextToken(data, dec.InputOffset())
+			key, err := dec.Token()
+			if err != nil {
+				return err
+			}
+			name := strings.ToLower(key.(string))
+			if path != "" {
+				name = path + "." + name
+			}
+			offsets[name] = column
+			if err := walkJSON(dec, data, name, offsets); err != nil {
+				return err
+			}
+		}
+	case json.Delim('['):
+		for i := 0; dec.More(); i++ {
+			name := path + "[" + strconv.Itoa(i) + "]"
+			offsets[name] = nextToken(data, dec.InputOffset())
+			if err := walkJSON(dec, data, name, offsets); err != nil {
+				return err
+			}
+		}
+	default:
+		return nil
+	}
+	_, err = dec.Token()
+	return err
+}
+
+// nextToken returns the 1-based column of the token following offset,
+// skipping white space and separators.
+func nextToken(data []byte, offset int64) int {
+	i := int(offset)
+	for i < len(data) && strings.IndexByte(" \t\r\n,:", data[i]) >= 0 {
+		i++
+	}
+	return i + 1
+}
+
+// jsonPath converts a validator field path of the struct type t, such as
+// "Address.City" or "Tags[1]", into the path of its key as recorded by
+// walkJSON. Embedded structs without a json name are flattened, as
+// encoding/json does.
+func jsonPath(t reflect.Type, field string) (string, bool) {
+	var path strings.Builder
+	for field != "" {
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if field[0] == '[' {
+			end := strings.IndexByte(field, ']')
+			if end < 0 {
+				return "", false
+			}
+			switch t.Kind() {
+			case reflect.Slice, reflect.Array:
+				path.WriteString(field[:end+1])
+			case reflect.Map:
+				path.WriteString("." + strings.ToLower(field[1:end]))
+			default:
+				return "", false
+			}
+			t, field = t.Elem(), field[end+1:]
+			continue
+		}
+		field = strings.TrimPrefix(field, ".")
+		name, rest := field, ""
+		if i := strings.IndexAny(field, ".["); i >= 0 {
+			name, rest = field[:i], field[i:]
+		}
+		if t.Kind() != reflect.Struct {
+			return "", false
+		}
+		sf, ok := t.FieldByName(name)
+		if !ok {
+			return "", false
+		}
+		key, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		switch {
+		case key == "-":
+			return "", false
+		case key == "" && sf.Anonymous && indirectType(sf.Type).Kind() == reflect.Struct:
+			// Flattened into the enclosing object.
+		default:
+			if key == "" {
+				key = sf.Name
+			}
+			if path.Len() > 0 {
+				path.WriteByte('.')
+			}
+			path.WriteString(strings.ToLower(key))
+		}
+		t, field = sf.Type, rest
+	}
+	return path.String(), true
+}
+
+// indirectType returns the type t points to, if it is a pointer.
+func indirectType(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// Record returns the record read by the last call to Next. It is the zero
+// value when the line could not be decoded.
+func (d *NDJSON[T, PT]) Record() T {
+	return d.rec
+}
+
+// Report returns the report of the record read by the last call to Next.
+func (d *NDJSON[T, PT]) Report() Report {
+	return d.report
+}
+
+// Err returns the error that stopped the reader, if any.
+func (d *NDJSON[T, PT]) Err() error {
+	return d.err
+}
@@ -0,0 +1,189 @@
+package stream
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/solrac97gr/validator"
+)
+
+type address struct {
+	City string `json:"city" csv:"city" validate:"required"`
+	Zip  string `json:"zip" validate:"omitempty,numeric,len=5"`
+}
+
+type pet struct {
+	Name string `json:"name" validate:"required"`
+}
+
+type user struct {
+	Name    string   `json:"name" validate:"required,min=3"`
+	Age     int      `json:"age" validate:"gte=18"`
+	Pets    []pet    `json:"pets" validate:"max=2"`
+	Address *address `json:"address"`
+}
+
+func (u *user) Validate(...interface{}) error { return nil }
+
+// issue is the comparable part of an Issue.
+type issue struct {
+	column int
+	field  string
+}
+
+// result is the expected outcome of a record.
+type result struct {
+	line   int
+	issues []issue
+}
+
+// collect reads all the records and returns their outcome.
+func collect(t *testing.T, next func() bool, report func() Report) []result {
+	t.Helper()
+	var got []result
+	for next() {
+		rep := report()
+		r := result{line: rep.Line}
+		for _, is := range rep.Issues {
+			if is.Err == nil {
+				t.Errorf("line %d: issue without error", rep.Line)
+			}
+			r.issues = append(r.issues, issue{is.Column, is.Field})
+		}
+		got = append(got, r)
+	}
+	return got
+}
+
+func equalResults(a, b []result) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i].line != b[i].line || len(a[i].issues) != len(b[i].issues) {
+			return false
+		}
+		for j := range a[i].issues {
+			if a[i].issues[j] != b[i].issues[j] {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestNDJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  []result
+	}{
+		{"empty", "", nil},
+		{"blank lines", "\n  \n\n", nil},
+		// Blank lines are skipped but counted.
+		{"valid", `{"name": "Ada", "age": 36}` + "\n\n" + `{"name": "Grace", "age": 85}`, []result{{1, nil}, {3, nil}}},
+		{"CRLF", "{\"name\": \"Ada\", \"age\": 36}\r\n{\"name\": \"Al\", \"age\": 36}\r\n", []result{{1, nil}, {2, []issue{{2, "Name"}}}}},
+		{"key columns", `{"age": 12, "name": "Al"}`, []result{{1, []issue{{13, "Name"}, {2, "Age"}}}}},
+		{"case-insensitive keys", `{"NAME": "Al", "age": 36}`, []result{{1, []issue{{2, "Name"}}}}},
+		{"missing field", `{"age": 36}`, []result{{1, []issue{{0, "Name"}}}}},
+		{"array elements", `{"name": "Ada", "age": 36, "pets": [{"name": "Rex"}, {"name": ""}]}`, []result{{1, []issue{{55, "Pets[1].Name"}}}}},
+		{"array element without the key", `{"name": "Ada", "age": 36, "pets": [{"name": "Rex"}, {}]}`, []result{{1, []issue{{54, "Pets[1].Name"}}}}},
+		{"nested", `{"name": "Ada", "age": 36, "address": {"zip": "123"}}`, []result{{1, []issue{{28, "Address.City"}, {40, "Address.Zip"}}}}},
+		{"syntax error", `{"name": "Ada",, "age": 36}` + "\n" + `{"name": "Ada", "age": 36}`, []result{{1, []issue{{16, ""}}}, {2, nil}}},
+		{"type error", `{"name": "Ada", "age": "36"}`, []result{{1, []issue{{27, "age"}}}}},
+		{"trailing data", `{"name": "Ada", "age": 36} {}`, []result{{1, []issue{{28, ""}}}}},
+	}
+	val := validator.NewValidator()
+	for _, tt := range tests {
+		r := NewNDJSON[user](strings.NewReader(tt.input), val)
+		if got := collect(t, r.Next, r.Report); !equalResults(got, tt.want) {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, tt.want)
+		}
+		if err := r.Err(); err != nil {
+			t.Errorf("%s: Err() = %v", tt.name, err)
+		}
+	}
+}
+
+func TestNDJSONRecords(t *testing.T) {
+	input := `{"name": "Ada", "age": 36}` + "\n" + `{"name": "Al"}` + "\n" + `{"name": 1}` + "\n"
+	r := NewNDJSON[user](strings.NewReader(input), validator.NewValidator())
+	var names []string
+	for r.Next() {
+		names = append(names, r.Record().Name)
+	}
+	// Records that fail validation are returned; those that cannot be
+	// decoded are zero.
+	if want := []string{"Ada", "Al", ""}; strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("records %q, want %q", names, want)
+	}
+}
+
+func TestNDJSONLineTooLong(t *testing.T) {
+	long := `{"name": "` + strings.Repeat("a", 100) + `", "age": 36}`
+	input := `{"name": "Ada", "age": 36}` + "\n" + long + "\n" + `{"name": "Al", "age": 36}` + "\n" + long
+	r := NewNDJSON[user](strings.NewReader(input), validator.NewValidator())
+	r.MaxLineLength = 64
+	var lines []int
+	var tooLong int
+	for r.Next() {
+		rep := r.Report()
+		lines = append(lines, rep.Line)
+		if errors.Is(rep.Err(), ErrLineTooLong) {
+			tooLong++
+			if r.Record().Name != "" {
+				t.Errorf("line %d: record %+v, want the zero value", rep.Line, r.Record())
+			}
+		}
+	}
+	if err := r.Err(); err != nil {
+		t.Fatalf("Err() = %v", err)
+	}
+	if len(lines) != 4 || lines[0] != 1 || lines[3] != 4 || tooLong != 2 {
+		t.Errorf("lines %v with %d too long, want [1 2 3 4] with 2", lines, tooLong)
+	}
+
+	// Lines longer than the read buffer are skipped as well.
+	huge := strings.Repeat("x", 10000)
+	r = NewNDJSON[user](strings.NewReader(huge+"\n"+`{"name": "Ada", "age": 36}`), validator.NewValidator())
+	r.MaxLineLength = 5000
+	got := collect(t, r.Next, r.Report)
+	if want := []result{{1, []issue{{0, ""}}}, {2, nil}}; !equalResults(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	// The limit excludes the newline.
+	r = NewNDJSON[user](strings.NewReader(`{"name": "Ada", "age": 36}`+"\n"), validator.NewValidator())
+	r.MaxLineLength = len(`{"name": "Ada", "age": 36}`)
+	if got := collect(t, r.Next, r.Report); !equalResults(got, []result{{1, nil}}) {
+		t.Errorf("line at the limit: got %+v", got)
+	}
+}
+
+func TestNDJSONReadError(t *testing.T) {
+	failure := errors.New("connection reset")
+	r := NewNDJSON[user](&failingReader{strings.NewReader(`{"name": "Ada", "age": 36}` + "\n"), failure}, validator.NewValidator())
+	if !r.Next() || !r.Report().Valid() {
+		t.Fatalf("first record: %v", r.Report().Err())
+	}
+	if r.Next() {
+		t.Fatal("Next() = true after a read error")
+	}
+	if err := r.Err(); !errors.Is(err, failure) {
+		t.Errorf("Err() = %v, want %v", err, failure)
+	}
+}
+
+// failingReader reads from r, then fails with err.
+type failingReader struct {
+	r   *strings.Reader
+	err error
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.r.Len() == 0 {
+		return 0, f.err
+	}
+	return f.r.Read(p)
+}
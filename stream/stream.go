@@ -0,0 +1,129 @@
+// Package stream validates bulk uploads record by record, without loading
+// them in memory. NDJSON and CSV readers decode each record into a struct,
+// or into a map with CSVMap, validate it and report the problems found
+// with their line and column:
+//
+//	r := stream.NewNDJSON[User](file, val)
+//	for r.Next() {
+//		if rep := r.Report(); !rep.Valid() {
+//			log.Printf("line %d: %v", rep.Line, rep.Err())
+//			continue
+//		}
+//		save(r.Record())
+//	}
+//	if err := r.Err(); err != nil {
+//		return err
+//	}
+package stream
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/solrac97gr/validator"
+)
+
+// ErrDecode is wrapped by the issues reported for records that could not
+// be decoded.
+var ErrDecode = errors.New("cannot decode record")
+
+// Issue is a problem found in a record.
+type Issue struct {
+	// Column is the 1-based column of the problem: the byte offset of the
+	// key in the line for NDJSON, the field number for CSV. It is 0 when
+	// unknown.
+	Column int
+	// Field is the path of the offending field, or "" for problems that
+	// are not tied to a field.
+	Field string
+	// Err is the error found. Validation failures are *validator.FieldError.
+	Err error
+}
+
+// Error implements the error interface.
+func (i Issue) Error() string {
+	if i.Column > 0 {
+		return fmt.Sprintf("column %d: %v", i.Column, i.Err)
+	}
+	return i.Err.Error()
+}
+
+// Unwrap returns the error found.
+func (i Issue) Unwrap() error {
+	return i.Err
+}
+
+// Issues is the list of problems found in a record.
+type Issues []Issue
+
+// Error implements the error interface.
+func (is Issues) Error() string {
+	msgs := make([]string, len(is))
+	for i, issue := range is {
+		msgs[i] = issue.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Unwrap returns the issues, so errors.Is and errors.As look into them.
+func (is Issues) Unwrap() []error {
+	errs := make([]error, len(is))
+	for i, issue := range is {
+		errs[i] = issue
+	}
+	return errs
+}
+
+// Report is the outcome of reading a record.
+type Report struct {
+	// Line is the 1-based line the record starts on.
+	Line int
+	// Issues lists the problems found, in the order of the fields.
+	Issues Issues
+}
+
+// Valid reports whether the record was decoded and passed validation.
+func (r Report) Valid() bool {
+	return len(r.Issues) == 0
+}
+
+// Err returns the issues of the record as an error, or nil when it is valid.
+func (r Report) Err() error {
+	if r.Valid() {
+		return nil
+	}
+	return r.Issues
+}
+
+// validate validates rec and turns its failures into issues. column maps
+// a field path to its column.
+func validate(val validator.Validator, rec validator.EvaluableStruct, column func(field string) int) Issues {
+	return issuesOf(val.Struct(rec), column)
+}
+
+// issuesOf turns the failures of err into issues.
+func issuesOf(err error, column func(field string) int) Issues {
+	if err == nil {
+		return nil
+	}
+	var errs validator.ValidationErrors
+	if !errors.As(err, &errs) {
+		var fe *validator.FieldError
+		if !errors.As(err, &fe) {
+			return Issues{{Err: err}}
+		}
+		errs = validator.ValidationErrors{fe}
+	}
+	issues := make(Issues, len(errs))
+	for i, fe := range errs {
+		issues[i] = Issue{Column: column(fe.Field), Field: fe.Field, Err: fe}
+	}
+	return issues
+}
+
+// recordPtr is satisfied by pointers to records that can be validated.
+type recordPtr[T any] interface {
+	*T
+	validator.EvaluableStruct
+}
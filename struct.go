@@ -0,0 +1,218 @@
+package validator
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// fieldMeta holds the validation metadata of a struct field.
+type fieldMeta struct {
+	index  int
+	name   string
+	groups map[string][]TagRule
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// fields returns the validation metadata of the exported fields of t and
+// of its unexported embedded structs, whose exported fields are promoted
+// as encoding/json does.
+func (v *ValidatorImpl) fields(t reflect.Type) []fieldMeta {
+	if cached, ok := v.cache.Load(t); ok {
+		return cached.([]fieldMeta)
+	}
+	var metas []fieldMeta
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get(TagName)
+		if !sf.IsExported() && !(sf.Anonymous && derefType(sf.Type).Kind() == reflect.Struct) || tag == "-" {
+			continue
+		}
+		metas = append(metas, fieldMeta{index: i, name: sf.Name, groups: ParseTagGroups(tag)})
+	}
+	v.cache.Store(t, metas)
+	return metas
+}
+
+// validateFields checks the validate tags of s and of any struct nested in
+// it against the rules of groups.
+func (v *ValidatorImpl) validateFields(s interface{}, groups Groups) error {
+	seen := make(ancestors)
+	rv, _ := seen.enter(reflect.ValueOf(s))
+	if rv.Kind() != reflect.Struct {
+		return nil
+	}
+	var errs ValidationErrors
+	v.walkStruct(rv, "", groups, seen, &errs)
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// walkStruct validates every field of rv against the rules of groups,
+// appending failures to errs. Only the first failure of a field is kept.
+func (v *ValidatorImpl) walkStruct(rv reflect.Value, prefix string, groups Groups, seen ancestors, errs *ValidationErrors) {
+	for _, f := range v.fields(rv.Type()) {
+		path := f.name
+		if prefix != "" {
+			path = prefix + "." + f.name
+		}
+		fv := rv.Field(f.index)
+		if fe := v.checkField(rv, f, fv, groups); fe != nil {
+			fe.Field = path
+			*errs = append(*errs, fe)
+			continue
+		}
+		v.dive(fv, path, groups, seen, errs)
+	}
+}
+
+// checkField runs the rules of each group against a field and returns the
+// first failure. Rules from the rule set replace the default group.
+func (v *ValidatorImpl) checkField(rv reflect.Value, f fieldMeta, value reflect.Value, groups Groups) *FieldError {
+	t := rv.Type()
+	for _, group := range groups {
+		rules := f.groups[group]
+		if group == DefaultGroup {
+			if override, ok := v.ruleSetRules(t, f.name); ok {
+				rules = override
+			}
+		}
+		if fe := v.checkValue(value, rules, fieldOf(rv)); fe != nil {
+			return fe
+		}
+	}
+	return nil
+}
+
+// checkValue runs rules against value and returns the first failure.
+// Cross-value rules compare value with the one returned by other, if any.
+func (v *ValidatorImpl) checkValue(value reflect.Value, rules []TagRule, other otherFunc) *FieldError {
+	value = indirect(value)
+	for _, r := range rules {
+		switch {
+		case r.Name == "omitempty":
+			if !value.IsValid() || value.IsZero() {
+				return nil
+			}
+			continue
+		case r.Name != "required" && !value.IsValid():
+			return nil
+		}
+		if cr, ok := crossRules[r.Name]; ok && other != nil {
+			// The other value is never rendered: it may be a secret, such as
+			// the password a confirmation is compared with.
+			label := r.Param
+			if label == "" {
+				label = "the other value"
+			}
+			if err := crossField(cr, value, other(r.Param), label); err != nil {
+				return &FieldError{Rule: r.Name, Param: r.Param, Err: err}
+			}
+			continue
+		}
+		if err := v.apply(r.Name, r.Param, value); err != nil {
+			return &FieldError{Rule: r.Name, Param: r.Param, Err: err}
+		}
+	}
+	return nil
+}
+
+// dive validates structs held by value, either directly or as elements of
+// slices, arrays and maps. Values already being walked by an enclosing
+// call are skipped, so self-referential graphs are walked once.
+func (v *ValidatorImpl) dive(value reflect.Value, path string, groups Groups, seen ancestors, errs *ValidationErrors) {
+	value, entered := seen.enter(value)
+	defer seen.leave(entered)
+	switch value.Kind() {
+	case reflect.Struct:
+		if value.Type() != timeType {
+			v.walkStruct(value, path, groups, seen, errs)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			v.dive(value.Index(i), path+"["+strconv.Itoa(i)+"]", groups, seen, errs)
+		}
+	case reflect.Map:
+		iter := value.MapRange()
+		for iter.Next() {
+			key := iter.Key()
+			name := key.String()
+			if key.Kind() != reflect.String {
+				name = valueString(key)
+			}
+			v.dive(iter.Value(), path+"["+name+"]", groups, seen, errs)
+		}
+	}
+}
+
+// ancestors records the pointers, maps and slices between the root of a
+// walk and the current value, to stop at cycles.
+type ancestors map[ancestor]bool
+
+// ancestor identifies a pointer, map or slice by its address and type.
+type ancestor struct {
+	ptr uintptr
+	typ reflect.Type
+}
+
+// enter dereferences value like indirect and records the references it
+// follows. It returns the zero Value when one of them is already recorded.
+// The returned ancestors must be passed to leave once value is walked.
+func (a ancestors) enter(value reflect.Value) (reflect.Value, []ancestor) {
+	var entered []ancestor
+	for {
+		switch value.Kind() {
+		case reflect.Interface:
+			if value.IsNil() {
+				return reflect.Value{}, entered
+			}
+			value = value.Elem()
+		case reflect.Ptr, reflect.Map, reflect.Slice:
+			if value.IsNil() {
+				return reflect.Value{}, entered
+			}
+			key := ancestor{value.Pointer(), value.Type()}
+			if a[key] {
+				return reflect.Value{}, entered
+			}
+			a[key] = true
+			entered = append(entered, key)
+			if value.Kind() != reflect.Ptr {
+				return value, entered
+			}
+			value = value.Elem()
+		default:
+			return value, entered
+		}
+	}
+}
+
+// leave forgets the ancestors recorded by enter.
+func (a ancestors) leave(entered []ancestor) {
+	for _, key := range entered {
+		delete(a, key)
+	}
+}
+
+// indirect dereferences pointers and interfaces. It returns the zero Value
+// when it meets a nil.
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	return value
+}
+
+// valueString formats a scalar value for use in error paths.
+func valueString(value reflect.Value) string {
+	if n, ok := toFloat(value); ok {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return value.String()
+}
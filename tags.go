@@ -0,0 +1,80 @@
+package validator
+
+import "strings"
+
+// TagName is the struct tag read by the validator.
+const TagName = "validate"
+
+// DefaultGroup is the group of the rules that are not assigned to one.
+const DefaultGroup = "default"
+
+// TagRule is a single rule parsed from a validate tag.
+type TagRule struct {
+	// Name is the name of the rule, e.g. "min".
+	Name string
+	// Param is the text after the "=" sign, e.g. "3".
+	Param string
+}
+
+// ParseTag parses the rules of the default group of a validate tag, such as
+// "required,min=3,max=20". A "-" tag yields no rules.
+func ParseTag(tag string) []TagRule {
+	return ParseTagGroups(tag)[DefaultGroup]
+}
+
+// ParseTagGroups parses a validate tag into the rules of each group. The
+// tag is made of sections separated by ";". A section may start with the
+// groups it belongs to, separated by "|" and followed by ":"; sections
+// without groups belong to DefaultGroup:
+//
+//	validate:"create:isdefault;update:required;alpha,max=20"
+func ParseTagGroups(tag string) map[string][]TagRule {
+	groups := make(map[string][]TagRule)
+	if tag == "-" {
+		return groups
+	}
+	for _, section := range strings.Split(tag, ";") {
+		names, rules := []string{DefaultGroup}, section
+		if prefix, rest, ok := strings.Cut(section, ":"); ok && isGroupList(prefix) {
+			names, rules = strings.Split(strings.TrimSpace(prefix), "|"), rest
+		}
+		parsed := parseRules(rules)
+		for _, name := range names {
+			groups[name] = append(groups[name], parsed...)
+		}
+	}
+	return groups
+}
+
+// parseRules parses a comma separated list of rules.
+func parseRules(list string) []TagRule {
+	var rules []TagRule
+	for _, part := range strings.Split(list, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, param, _ := strings.Cut(part, "=")
+		rules = append(rules, TagRule{Name: strings.TrimSpace(name), Param: strings.TrimSpace(param)})
+	}
+	return rules
+}
+
+// isGroupList reports whether s is a "|" separated list of group names.
+func isGroupList(s string) bool {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return false
+	}
+	for _, name := range strings.Split(s, "|") {
+		if name == "" {
+			return false
+		}
+		for _, r := range name {
+			if !(r == '_' || r == '-' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z') {
+				return false
+			}
+		}
+	}
+	return true
+}
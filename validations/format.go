@@ -27,6 +27,10 @@ var (
 	ErrInvalidDatetime        = errors.New("invalid Datetime")
 	ErrInvalidE164PhoneNumber = errors.New("invalid E.164 phone number")
 	ErrInvalidEmail           = errors.New("invalid email address")
+	// ErrInvalidEthAddress is returned when the provided string is not a valid Ethereum address.
+	ErrInvalidEthAddress = errors.New("invalid Ethereum address")
+	// ErrInvalidRFC3339Datetime is returned when the provided string is not an RFC 3339 date-time.
+	ErrInvalidRFC3339Datetime = errors.New("invalid RFC 3339 date-time")
 )
 
 const (
@@ -256,6 +260,15 @@ func IsValidDatetime(str string) error {
 	return nil
 }
 
+// IsValidRFC3339Datetime checks if the given string is a valid RFC 3339 date-time, such as "2006-01-02T15:04:05Z".
+func IsValidRFC3339Datetime(str string) error {
+	_, err := time.Parse(time.RFC3339Nano, str)
+	if err != nil {
+		return ErrInvalidRFC3339Datetime
+	}
+	return nil
+}
+
 // IsValidE164PhoneNumber checks if the given string is a valid E.164 phone number.
 func IsValidE164PhoneNumber(str string) error {
 	matched, err := regexp.MatchString(`^\+[1-9]\d{1,14}$`, str)
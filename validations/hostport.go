@@ -0,0 +1,107 @@
+package validations
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidHostPort is returned when an address is not a valid host:port pair.
+	ErrInvalidHostPort = errors.New("invalid host:port address")
+	// ErrInvalidPort is returned when a port is not a number between 1 and 65535.
+	ErrInvalidPort = errors.New("invalid port")
+)
+
+// HostPortOptions tunes ValidateHostPort.
+type HostPortOptions struct {
+	// Family restricts IP literals to IPv4 (4) or IPv6 (6). Zero accepts
+	// both. Hostnames are accepted for any family.
+	Family int
+	// RequireIP rejects hostnames, accepting IP literals only.
+	RequireIP bool
+	// AllowZeroPort accepts port 0, which lets the system pick a port.
+	AllowZeroPort bool
+	// AllowEmptyHost accepts addresses without a host, such as ":8080".
+	AllowEmptyHost bool
+}
+
+// ValidateHostPort checks that addr is a host and a port separated by a
+// colon, without resolving anything. The host is a hostname or an IP
+// literal, IPv6 literals being enclosed in brackets, and the port is a
+// number between 1 and 65535.
+//
+//	ValidateHostPort("[::1]:8080", HostPortOptions{RequireIP: true})
+func ValidateHostPort(addr string, opts HostPortOptions) error {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("%w: %q", ErrInvalidHostPort, addr)
+	}
+	if err := checkPort(port, opts.AllowZeroPort); err != nil {
+		return err
+	}
+	bracketed := strings.HasPrefix(addr, "[")
+	if host == "" {
+		if !opts.AllowEmptyHost || bracketed {
+			return fmt.Errorf("%w: missing host", ErrInvalidHostPort)
+		}
+		return nil
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		if bracketed || opts.RequireIP {
+			return fmt.Errorf("%w: %q is not an IP address", ErrInvalidHostPort, host)
+		}
+		if err := checkHost(host); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidHostPort, err)
+		}
+		return nil
+	}
+	switch {
+	case bracketed != ip.Is6():
+		return fmt.Errorf("%w: only IPv6 addresses are enclosed in brackets", ErrInvalidHostPort)
+	case opts.Family == 4 && !ip.Is4():
+		return fmt.Errorf("%w: %s", ErrExpectedIPv4Address, host)
+	case opts.Family == 6 && !ip.Is6():
+		return fmt.Errorf("%w: %s", ErrExpectedIPv6Address, host)
+	}
+	return nil
+}
+
+// ValidateHostnamePort checks that addr is a hostname or an IP literal
+// followed by a port between 1 and 65535.
+func ValidateHostnamePort(addr string) error {
+	return ValidateHostPort(addr, HostPortOptions{})
+}
+
+// ValidateIPPort checks that addr is an IP literal followed by a port
+// between 1 and 65535.
+func ValidateIPPort(addr string) error {
+	return ValidateHostPort(addr, HostPortOptions{RequireIP: true})
+}
+
+// checkPort checks that port is a decimal number in range.
+func checkPort(port string, allowZero bool) error {
+	n, err := strconv.ParseUint(port, 10, 16)
+	if err != nil || n == 0 && !allowZero {
+		return fmt.Errorf("%w: %q", ErrInvalidPort, port)
+	}
+	return nil
+}
+
+// checkHost checks the syntax of a hostname. A numeric last label is
+// rejected, so malformed IPv4 addresses are not taken for names.
+func checkHost(host string) error {
+	if err := ValidateHostname(host); err != nil {
+		return err
+	}
+	name := strings.TrimSuffix(host, ".")
+	if last := name[strings.LastIndex(name, ".")+1:]; strings.Trim(last, "0123456789") == "" {
+		return fmt.Errorf("%q is not a valid IP address or hostname", host)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package validations
+
+import (
+	"errors"
+	"net"
+	"net/netip"
+	"strings"
+	"testing"
+)
+
+func TestValidateHostPort(t *testing.T) {
+	tests := []struct {
+		addr string
+		opts HostPortOptions
+		want error
+	}{
+		{"example.com:443", HostPortOptions{}, nil},
+		{"127.0.0.1:8080", HostPortOptions{}, nil},
+		{"[::1]:8080", HostPortOptions{RequireIP: true}, nil},
+		{"[fe80::1%eth0]:80", HostPortOptions{Family: 6}, nil},
+		{":8080", HostPortOptions{AllowEmptyHost: true}, nil},
+		{"127.0.0.1:0", HostPortOptions{AllowZeroPort: true}, nil},
+
+		{"example.com", HostPortOptions{}, ErrInvalidHostPort},
+		{"::1:80", HostPortOptions{}, ErrInvalidHostPort},
+		{":8080", HostPortOptions{}, ErrInvalidHostPort},
+		{"[]:8080", HostPortOptions{AllowEmptyHost: true}, ErrInvalidHostPort},
+		{"[1.2.3.4]:80", HostPortOptions{}, ErrInvalidHostPort},
+		{"[example.com]:80", HostPortOptions{}, ErrInvalidHostPort},
+		{"example.com:80", HostPortOptions{RequireIP: true}, ErrInvalidHostPort},
+		{"exa_mple.com:80", HostPortOptions{}, ErrInvalidHostPort},
+		{"1.2.3:80", HostPortOptions{}, ErrInvalidHostPort},
+		{"[::1]:80", HostPortOptions{Family: 4}, ErrExpectedIPv4Address},
+		{"1.2.3.4:80", HostPortOptions{Family: 6}, ErrExpectedIPv6Address},
+		{"127.0.0.1:0", HostPortOptions{}, ErrInvalidPort},
+		{"127.0.0.1:65536", HostPortOptions{}, ErrInvalidPort},
+		{"127.0.0.1:http", HostPortOptions{}, ErrInvalidPort},
+		{"127.0.0.1:", HostPortOptions{}, ErrInvalidPort},
+	}
+	for _, tt := range tests {
+		if err := ValidateHostPort(tt.addr, tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateHostPort(%q, %+v) = %v, want %v", tt.addr, tt.opts, err, tt.want)
+		}
+	}
+}
+
+func TestNetworkAddr(t *testing.T) {
+	validators := map[string]func(string) error{
+		"tcp": ValidateTCPAddr, "tcp4": ValidateTCP4Addr, "tcp6": ValidateTCP6Addr,
+		"udp": ValidateUDPAddr, "udp4": ValidateUDP4Addr, "udp6": ValidateUDP6Addr,
+	}
+	tests := []struct {
+		addr  string
+		valid []string // networks accepting addr
+	}{
+		{"127.0.0.1:80", []string{"tcp", "tcp4", "udp", "udp4"}},
+		{"[::1]:80", []string{"tcp", "tcp6", "udp", "udp6"}},
+		{"[fe80::1%eth0]:80", []string{"tcp", "tcp6", "udp", "udp6"}},
+		{"example.com:80", []string{"tcp", "tcp4", "tcp6", "udp", "udp4", "udp6"}},
+		{":0", []string{"tcp", "tcp4", "tcp6", "udp", "udp4", "udp6"}},
+		// Empty ports are 0.
+		{"127.0.0.1:", []string{"tcp", "tcp4", "udp", "udp4"}},
+		{":", []string{"tcp", "tcp4", "tcp6", "udp", "udp4", "udp6"}},
+		// IPv4-mapped IPv6 addresses are IPv4 addresses.
+		{"[::ffff:1.2.3.4]:80", []string{"tcp", "tcp4", "udp", "udp4"}},
+		{"[1.2.3.4]:80", []string{"tcp", "tcp4", "udp", "udp4"}},
+		// Service names are looked up for the network.
+		{"127.0.0.1:https", []string{"tcp", "tcp4", "udp", "udp4"}},
+		{"[::1]:HTTP", []string{"tcp", "tcp6"}},
+		{":domain", []string{"tcp", "tcp4", "tcp6", "udp", "udp4", "udp6"}},
+		{"127.0.0.1:nosuch", nil},
+		{"127.0.0.1:65536", nil},
+		{"127.0.0.1:-1", nil},
+		{"::1:80", nil},
+		{"exa mple.com:80", nil},
+		{"example.com", nil},
+		// Ports with a sign are rejected, unlike net.ResolveTCPAddr.
+		{"127.0.0.1:+80", nil},
+	}
+	for _, tt := range tests {
+		for network, validate := range validators {
+			valid := false
+			for _, n := range tt.valid {
+				valid = valid || n == network
+			}
+			if err := validate(tt.addr); (err == nil) != valid {
+				t.Errorf("%s address %q: %v, want valid %v", network, tt.addr, err, valid)
+			}
+		}
+	}
+}
+
+// TestNetworkAddrResolve checks that the validators accept the literal
+// addresses accepted by net.ResolveTCPAddr and net.ResolveUDPAddr, which
+// they replaced.
+func TestNetworkAddrResolve(t *testing.T) {
+	resolve := map[string]func(network, addr string) error{
+		"tcp": func(network, addr string) error { _, err := net.ResolveTCPAddr(network, addr); return err },
+		"udp": func(network, addr string) error { _, err := net.ResolveUDPAddr(network, addr); return err },
+	}
+	validators := map[string]func(string) error{
+		"tcp": ValidateTCPAddr, "tcp4": ValidateTCP4Addr, "tcp6": ValidateTCP6Addr,
+		"udp": ValidateUDPAddr, "udp4": ValidateUDP4Addr, "udp6": ValidateUDP6Addr,
+	}
+	addrs := []string{
+		"127.0.0.1:80", "0.0.0.0:0", "127.0.0.1:", ":", ":80", "[]:80",
+		"[::1]:80", "[::]:443", "[::%eth0]:1", "[::ffff:1.2.3.4%eth0]:1", "[fe80::1%eth0]:80", "[::ffff:1.2.3.4]:80", "[1.2.3.4]:80",
+		"127.0.0.1:0080", "127.0.0.1:65535", "127.0.0.1:65536", "127.0.0.1:-1",
+		"::1:80", "127.0.0.1", "[::1]", "[::1:80",
+	}
+	for _, addr := range addrs {
+		// Only literal addresses are compared, names would hit DNS.
+		if host, _, err := net.SplitHostPort(addr); err == nil && host != "" {
+			if _, err := netip.ParseAddr(host); err != nil {
+				t.Fatalf("%q is not a literal address", addr)
+			}
+		}
+		for network, validate := range validators {
+			want := resolve[strings.TrimRight(network, "46")](network, addr)
+			if err := validate(addr); (err == nil) != (want == nil) {
+				t.Errorf("%s address %q: %v, net accepts it: %v", network, addr, err, want == nil)
+			}
+		}
+	}
+}
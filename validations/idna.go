@@ -0,0 +1,176 @@
+package validations
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"golang.org/x/net/idna"
+)
+
+var (
+	// ErrInvalidIDNHostname is returned when a string is not a valid internationalized hostname.
+	ErrInvalidIDNHostname = errors.New("invalid internationalized hostname")
+	// ErrMixedScripts is returned when a label of a hostname mixes scripts, such as Latin and Cyrillic.
+	ErrMixedScripts = errors.New("hostname label mixes scripts")
+	// ErrHostnameNotASCII is returned when a hostname must be in its ASCII form and is not.
+	ErrHostnameNotASCII = errors.New("hostname must be in ASCII form")
+)
+
+// IDNOptions tunes ValidateIDNHostname.
+type IDNOptions struct {
+	// ASCIIOnly accepts the ASCII form only, where Unicode labels are
+	// encoded as punycode "xn--" labels, such as "xn--mnchen-3ya.de".
+	ASCIIOnly bool
+	// AllowMixedScripts accepts labels mixing scripts, such as "pаypal"
+	// with a Cyrillic "а", which are rejected by default because they can
+	// impersonate other names.
+	AllowMixedScripts bool
+}
+
+// ValidateIDNHostname checks that host is a valid hostname under IDNA 2008,
+// in its Unicode form, such as "münchen.de", or its ASCII form. Punycode
+// labels must decode to valid Unicode labels, and the ASCII form must
+// follow the rules of ValidateFQDN for label and name lengths.
+func ValidateIDNHostname(host string, opts IDNOptions) error {
+	if opts.ASCIIOnly {
+		for i := 0; i < len(host); i++ {
+			if host[i] >= utf8.RuneSelf {
+				return ErrHostnameNotASCII
+			}
+		}
+	}
+	ascii, err := HostnameToASCII(host)
+	if err != nil {
+		return err
+	}
+	if opts.AllowMixedScripts {
+		return nil
+	}
+	unicodeHost, err := idna.Lookup.ToUnicode(ascii)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidIDNHostname, err)
+	}
+	for _, label := range strings.Split(unicodeHost, ".") {
+		if scripts := labelScripts(label); !allowedScripts(scripts) {
+			return fmt.Errorf("%w: %q uses %s", ErrMixedScripts, label, strings.Join(scripts, ", "))
+		}
+	}
+	return nil
+}
+
+// ValidateUnicodeHostname checks that host is a valid internationalized
+// hostname, in its Unicode or ASCII form, without mixed-script labels.
+func ValidateUnicodeHostname(host string) error {
+	return ValidateIDNHostname(host, IDNOptions{})
+}
+
+// ValidatePunycodeHostname checks that host is the ASCII form of a valid
+// internationalized hostname, without mixed-script labels.
+func ValidatePunycodeHostname(host string) error {
+	return ValidateIDNHostname(host, IDNOptions{ASCIIOnly: true})
+}
+
+// HostnameToASCII returns the ASCII form of an internationalized hostname,
+// in lowercase, with Unicode labels encoded as punycode, so "München.de"
+// becomes "xn--mnchen-3ya.de".
+func HostnameToASCII(host string) (string, error) {
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidIDNHostname, err)
+	}
+	name := strings.TrimSuffix(ascii, ".")
+	if name == "" {
+		return "", fmt.Errorf("%w: empty name", ErrInvalidIDNHostname)
+	}
+	if len(name) > 253 {
+		return "", fmt.Errorf("%w: name is longer than 253 characters", ErrInvalidIDNHostname)
+	}
+	for _, label := range strings.Split(name, ".") {
+		if err := checkLabel(label); err != nil {
+			return "", fmt.Errorf("%w: %v", ErrInvalidIDNHostname, err)
+		}
+	}
+	return ascii, nil
+}
+
+// HostnameToUnicode returns the Unicode form of an internationalized
+// hostname, decoding its punycode labels, so "xn--mnchen-3ya.de" becomes
+// "münchen.de".
+func HostnameToUnicode(host string) (string, error) {
+	ascii, err := HostnameToASCII(host)
+	if err != nil {
+		return "", err
+	}
+	unicodeHost, err := idna.Lookup.ToUnicode(ascii)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrInvalidIDNHostname, err)
+	}
+	return unicodeHost, nil
+}
+
+// NormalizeIDNHostname returns the ASCII form of an internationalized
+// hostname, as HostnameToASCII does. Invalid hostnames are returned
+// unchanged.
+func NormalizeIDNHostname(host string) string {
+	ascii, err := HostnameToASCII(strings.TrimSpace(host))
+	if err != nil {
+		return host
+	}
+	return ascii
+}
+
+// labelScripts returns the sorted names of the scripts used by label,
+// ignoring the characters shared by all scripts, such as digits and
+// hyphens.
+func labelScripts(label string) []string {
+	seen := make(map[string]bool)
+	for _, r := range label {
+		if unicode.In(r, unicode.Common, unicode.Inherited) {
+			continue
+		}
+		for name, table := range unicode.Scripts {
+			if unicode.Is(table, r) {
+				seen[name] = true
+				break
+			}
+		}
+	}
+	scripts := make([]string, 0, len(seen))
+	for name := range seen {
+		scripts = append(scripts, name)
+	}
+	sort.Strings(scripts)
+	return scripts
+}
+
+// scriptMixes lists the combinations of scripts commonly written together,
+// following the highly restrictive level of Unicode Technical Standard 39.
+var scriptMixes = [][]string{
+	{"Latin", "Han", "Hiragana", "Katakana"},
+	{"Latin", "Han", "Bopomofo"},
+	{"Latin", "Han", "Hangul"},
+}
+
+// allowedScripts reports whether a label may use all of scripts.
+func allowedScripts(scripts []string) bool {
+	if len(scripts) <= 1 {
+		return true
+	}
+	for _, mix := range scriptMixes {
+		ok := true
+		for _, s := range scripts {
+			if !containsFold(mix, s) {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,141 @@
+package validations
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateIDNHostname(t *testing.T) {
+	tests := []struct {
+		host string
+		opts IDNOptions
+		want error
+	}{
+		{"example.com", IDNOptions{}, nil},
+		{"münchen.de", IDNOptions{}, nil},
+		{"München.DE.", IDNOptions{}, nil},
+		{"xn--mnchen-3ya.de", IDNOptions{}, nil},
+		{"münchen-2024.de", IDNOptions{}, nil},
+		{"пример.рф", IDNOptions{}, nil},
+		{"παράδειγμα.ελ", IDNOptions{}, nil},
+
+		// ASCIIOnly accepts the punycode form only.
+		{"xn--mnchen-3ya.de", IDNOptions{ASCIIOnly: true}, nil},
+		{"münchen.de", IDNOptions{ASCIIOnly: true}, ErrHostnameNotASCII},
+
+		// Latin and Cyrillic are not written together.
+		{"pаypal.com", IDNOptions{}, ErrMixedScripts},
+		{"xn--pypal-4ve.com", IDNOptions{ASCIIOnly: true}, ErrMixedScripts},
+		{"pаypal.com", IDNOptions{AllowMixedScripts: true}, nil},
+		{"αβc.com", IDNOptions{}, ErrMixedScripts},
+		// Japanese mixes Han, Hiragana, Katakana and Latin, Chinese and
+		// Korean mix Han with Bopomofo and Hangul.
+		{"日本語ひらがなカタカナ.jp", IDNOptions{}, nil},
+		{"東京abc.jp", IDNOptions{}, nil},
+		{"中文ㄅㄆ.tw", IDNOptions{}, nil},
+		{"한국어漢字.kr", IDNOptions{}, nil},
+		{"ひらがな한국.com", IDNOptions{}, ErrMixedScripts},
+		{"カタカナㄅ.com", IDNOptions{}, ErrMixedScripts},
+		// Each label is checked on its own.
+		{"пример.example.com", IDNOptions{}, nil},
+
+		{"", IDNOptions{}, ErrInvalidIDNHostname},
+		{"a..de", IDNOptions{}, ErrInvalidIDNHostname},
+		{"-bad-.de", IDNOptions{}, ErrInvalidIDNHostname},
+		{"exa mple.com", IDNOptions{}, ErrInvalidIDNHostname},
+		{"xn--a.com", IDNOptions{}, ErrInvalidIDNHostname},
+		{strings.Repeat("ü", 60) + ".de", IDNOptions{}, ErrInvalidIDNHostname},
+	}
+	for _, tt := range tests {
+		if err := ValidateIDNHostname(tt.host, tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateIDNHostname(%q, %+v) = %v, want %v", tt.host, tt.opts, err, tt.want)
+		}
+	}
+
+	if err := ValidateUnicodeHostname("pаypal.com"); !errors.Is(err, ErrMixedScripts) {
+		t.Errorf("ValidateUnicodeHostname() = %v, want %v", err, ErrMixedScripts)
+	}
+	if err := ValidatePunycodeHostname("münchen.de"); !errors.Is(err, ErrHostnameNotASCII) {
+		t.Errorf("ValidatePunycodeHostname() = %v, want %v", err, ErrHostnameNotASCII)
+	}
+}
+
+func TestHostnameConversions(t *testing.T) {
+	tests := []struct {
+		host, ascii, unicode string
+	}{
+		{"example.com", "example.com", "example.com"},
+		{"München.DE", "xn--mnchen-3ya.de", "münchen.de"},
+		{"xn--mnchen-3ya.de", "xn--mnchen-3ya.de", "münchen.de"},
+		{"bücher.example.", "xn--bcher-kva.example.", "bücher.example."},
+		{"пример.рф", "xn--e1afmkfd.xn--p1ai", "пример.рф"},
+		{"日本語.jp", "xn--wgv71a119e.jp", "日本語.jp"},
+	}
+	for _, tt := range tests {
+		if got, err := HostnameToASCII(tt.host); got != tt.ascii || err != nil {
+			t.Errorf("HostnameToASCII(%q) = %q, %v, want %q", tt.host, got, err, tt.ascii)
+		}
+		if got, err := HostnameToUnicode(tt.host); got != tt.unicode || err != nil {
+			t.Errorf("HostnameToUnicode(%q) = %q, %v, want %q", tt.host, got, err, tt.unicode)
+		}
+	}
+
+	for _, host := range []string{"", ".", "a..de", "xn--a.com", "exa_mple.com", strings.Repeat("a.", 127) + "de"} {
+		if _, err := HostnameToASCII(host); !errors.Is(err, ErrInvalidIDNHostname) {
+			t.Errorf("HostnameToASCII(%q) = %v, want %v", host, err, ErrInvalidIDNHostname)
+		}
+		if _, err := HostnameToUnicode(host); !errors.Is(err, ErrInvalidIDNHostname) {
+			t.Errorf("HostnameToUnicode(%q) = %v, want %v", host, err, ErrInvalidIDNHostname)
+		}
+	}
+
+	if got := NormalizeIDNHostname(" München.de "); got != "xn--mnchen-3ya.de" {
+		t.Errorf("NormalizeIDNHostname() = %q", got)
+	}
+	if got := NormalizeIDNHostname("a..de"); got != "a..de" {
+		t.Errorf("NormalizeIDNHostname() = %q, want the hostname unchanged", got)
+	}
+}
+
+func TestLabelScripts(t *testing.T) {
+	tests := []struct {
+		label string
+		want  []string
+	}{
+		{"example", []string{"Latin"}},
+		{"2024-", []string{}},
+		{"pаypal", []string{"Cyrillic", "Latin"}},
+		{"日本語ひらがなカタカナ", []string{"Han", "Hiragana", "Katakana"}},
+	}
+	for _, tt := range tests {
+		if got := labelScripts(tt.label); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("labelScripts(%q) = %q, want %q", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestAllowedScripts(t *testing.T) {
+	tests := []struct {
+		scripts []string
+		want    bool
+	}{
+		{nil, true},
+		{[]string{"Cyrillic"}, true},
+		{[]string{"Arabic"}, true},
+		{[]string{"Han", "Hiragana", "Katakana", "Latin"}, true},
+		{[]string{"Han", "Katakana"}, true},
+		{[]string{"Bopomofo", "Han", "Latin"}, true},
+		{[]string{"Han", "Hangul"}, true},
+		{[]string{"Cyrillic", "Latin"}, false},
+		{[]string{"Greek", "Latin"}, false},
+		{[]string{"Hangul", "Hiragana"}, false},
+		{[]string{"Bopomofo", "Hangul"}, false},
+	}
+	for _, tt := range tests {
+		if got := allowedScripts(tt.scripts); got != tt.want {
+			t.Errorf("allowedScripts(%q) = %v, want %v", tt.scripts, got, tt.want)
+		}
+	}
+}
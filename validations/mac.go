@@ -0,0 +1,144 @@
+package validations
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+)
+
+var (
+	// ErrMACLength is returned when a MAC address does not have an allowed length.
+	ErrMACLength = errors.New("MAC address length is not allowed")
+	// ErrMACNotUnicast is returned when a MAC address must be unicast and is multicast.
+	ErrMACNotUnicast = errors.New("MAC address is not unicast")
+	// ErrMACNotMulticast is returned when a MAC address must be multicast and is unicast.
+	ErrMACNotMulticast = errors.New("MAC address is not multicast")
+	// ErrMACNotUniversal is returned when a MAC address must be universally administered and is not.
+	ErrMACNotUniversal = errors.New("MAC address is not universally administered")
+	// ErrMACNotLocal is returned when a MAC address must be locally administered and is not.
+	ErrMACNotLocal = errors.New("MAC address is not locally administered")
+)
+
+// MACOptions tunes ValidateMAC.
+type MACOptions struct {
+	// Lengths lists the allowed lengths in bytes: 6 for EUI-48, 8 for
+	// EUI-64 and 20 for IP over InfiniBand. It defaults to all three.
+	Lengths []int
+	// Unicast rejects multicast addresses, whose I/G bit is set.
+	Unicast bool
+	// Multicast rejects unicast addresses.
+	Multicast bool
+	// Universal rejects locally administered addresses, whose U/L bit is
+	// set, such as the random addresses of virtual machines.
+	Universal bool
+	// Local rejects universally administered addresses.
+	Local bool
+}
+
+// ValidateMAC checks that mac is a MAC address in one of the formats
+// accepted by net.ParseMAC, with the same separator between all groups:
+//
+//	00:00:5e:00:53:01
+//	00-00-5E-00-53-01
+//	0000.5e00.5301
+//	02:00:5e:10:00:00:00:01
+//
+// opts restricts the length and the kind of address.
+func ValidateMAC(mac string, opts MACOptions) error {
+	hw, err := ParseMAC(mac)
+	if err != nil {
+		return err
+	}
+	if len(opts.Lengths) > 0 && !slices.Contains(opts.Lengths, len(hw)) {
+		return fmt.Errorf("%w: %d bytes", ErrMACLength, len(hw))
+	}
+	multicast, local := hw[0]&0x01 != 0, hw[0]&0x02 != 0
+	switch {
+	case opts.Unicast && multicast:
+		return ErrMACNotUnicast
+	case opts.Multicast && !multicast:
+		return ErrMACNotMulticast
+	case opts.Universal && local:
+		return ErrMACNotUniversal
+	case opts.Local && !local:
+		return ErrMACNotLocal
+	}
+	return nil
+}
+
+// ValidateMAC48 checks that mac is a 6-byte EUI-48 MAC address.
+func ValidateMAC48(mac string) error {
+	return ValidateMAC(mac, MACOptions{Lengths: []int{6}})
+}
+
+// ValidateEUI64 checks that mac is an 8-byte EUI-64 address.
+func ValidateEUI64(mac string) error {
+	return ValidateMAC(mac, MACOptions{Lengths: []int{8}})
+}
+
+// ValidateUnicastMAC checks that mac is a unicast MAC address.
+func ValidateUnicastMAC(mac string) error {
+	return ValidateMAC(mac, MACOptions{Unicast: true})
+}
+
+// ValidateMulticastMAC checks that mac is a multicast MAC address.
+func ValidateMulticastMAC(mac string) error {
+	return ValidateMAC(mac, MACOptions{Multicast: true})
+}
+
+// ValidateUniversalMAC checks that mac is a universally administered MAC
+// address, assigned by its manufacturer.
+func ValidateUniversalMAC(mac string) error {
+	return ValidateMAC(mac, MACOptions{Universal: true})
+}
+
+// ValidateLocalMAC checks that mac is a locally administered MAC address.
+func ValidateLocalMAC(mac string) error {
+	return ValidateMAC(mac, MACOptions{Local: true})
+}
+
+// ParseMAC parses a MAC address like net.ParseMAC, explaining why it is
+// invalid: groups of two hexadecimal digits separated by colons or
+// hyphens, or groups of four separated by dots, for 6, 8 or 20 bytes.
+func ParseMAC(mac string) (net.HardwareAddr, error) {
+	if mac == "" {
+		return nil, ErrEmptyMACAddress
+	}
+	var sep byte
+	var size int
+	switch {
+	case len(mac) > 4 && mac[4] == '.':
+		sep, size = '.', 4
+	case len(mac) > 2 && (mac[2] == ':' || mac[2] == '-'):
+		sep, size = mac[2], 2
+	default:
+		return nil, fmt.Errorf("%w: expected groups separated by colons, hyphens or dots", ErrInvalidMACAddress)
+	}
+	if (len(mac)+1)%(size+1) != 0 {
+		return nil, fmt.Errorf("%w: groups must have %d digits", ErrInvalidMACAddress, size)
+	}
+	digits := make([]byte, 0, len(mac))
+	for i := 0; i < len(mac); i += size + 1 {
+		if end := i + size; end < len(mac) && mac[end] != sep {
+			if mac[end] == ':' || mac[end] == '-' || mac[end] == '.' {
+				return nil, fmt.Errorf("%w: mixed separators %q and %q", ErrInvalidMACAddress, sep, mac[end])
+			}
+			return nil, fmt.Errorf("%w: groups must have %d digits", ErrInvalidMACAddress, size)
+		}
+		digits = append(digits, mac[i:i+size]...)
+	}
+	for _, c := range digits {
+		if !isHexCharacter(c) {
+			return nil, fmt.Errorf("%w: %q is not a hexadecimal digit", ErrInvalidMACAddress, c)
+		}
+	}
+	hw := make(net.HardwareAddr, hex.DecodedLen(len(digits)))
+	hex.Decode(hw, digits)
+	switch len(hw) {
+	case 6, 8, 20:
+		return hw, nil
+	}
+	return nil, fmt.Errorf("%w: %d bytes instead of 6, 8 or 20", ErrInvalidMACAddress, len(hw))
+}
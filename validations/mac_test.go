@@ -0,0 +1,131 @@
+package validations
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestParseMAC(t *testing.T) {
+	infiniband := "00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01"
+	valid := []string{
+		"00:00:5e:00:53:01",
+		"00-00-5E-00-53-01",
+		"0000.5e00.5301",
+		"02:00:5e:10:00:00:00:01",
+		"0200.5e10.0000.0001",
+		infiniband,
+		strings.ReplaceAll(infiniband, ":", "-"),
+		"0000.0000.fe80.0000.0000.0000.0200.5e10.0000.0001",
+	}
+	for _, mac := range valid {
+		got, err := ParseMAC(mac)
+		if err != nil {
+			t.Errorf("ParseMAC(%q) = %v", mac, err)
+			continue
+		}
+		if want, _ := net.ParseMAC(mac); !bytes.Equal(got, want) {
+			t.Errorf("ParseMAC(%q) = %v, net.ParseMAC gives %v", mac, got, want)
+		}
+	}
+
+	invalid := []struct {
+		mac  string
+		want string
+	}{
+		{"00:00:5e-00:53:01", "mixed separators"},
+		{"00-00-5e-00-53:01", "mixed separators"},
+		{"0000.5e00:5301", "mixed separators"},
+		{"00:00:5e:00:53:0", "groups must have 2 digits"},
+		{"000:05e:005:301", "expected groups separated"},
+		{"00:00:5e:00:5:301", "groups must have 2 digits"},
+		{"0000.5e0.05301", "groups must have 4 digits"},
+		{"00005e005301", "expected groups separated"},
+		{"00 00 5e 00 53 01", "expected groups separated"},
+		{"00:00:5e:00:53:0g", "not a hexadecimal digit"},
+		{"00:00:5e:00:53", "5 bytes"},
+		{"00:00:5e:00:53:01:02", "7 bytes"},
+		{"0000.5e00", "4 bytes"},
+		{infiniband + ":00", "21 bytes"},
+	}
+	for _, tt := range invalid {
+		_, err := ParseMAC(tt.mac)
+		if !errors.Is(err, ErrInvalidMACAddress) || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseMAC(%q) = %v, want %v: %s", tt.mac, err, ErrInvalidMACAddress, tt.want)
+		}
+	}
+	if _, err := ParseMAC(""); !errors.Is(err, ErrEmptyMACAddress) {
+		t.Errorf("ParseMAC(\"\") = %v, want %v", err, ErrEmptyMACAddress)
+	}
+}
+
+func TestValidateMAC(t *testing.T) {
+	const (
+		universalUnicast   = "00:00:5e:00:53:01"
+		localUnicast       = "02:42:ac:11:00:02"
+		universalMulticast = "01:00:5e:00:00:fb"
+		localMulticast     = "33:33:00:00:00:01"
+		eui64              = "00:00:5e:ef:10:00:00:01"
+	)
+	tests := []struct {
+		mac  string
+		opts MACOptions
+		want error
+	}{
+		{universalUnicast, MACOptions{}, nil},
+		{eui64, MACOptions{}, nil},
+		{universalUnicast, MACOptions{Lengths: []int{6}}, nil},
+		{eui64, MACOptions{Lengths: []int{6}}, ErrMACLength},
+		{universalUnicast, MACOptions{Lengths: []int{8, 20}}, ErrMACLength},
+
+		// The I/G bit tells multicast addresses apart.
+		{universalUnicast, MACOptions{Unicast: true}, nil},
+		{localUnicast, MACOptions{Unicast: true}, nil},
+		{universalMulticast, MACOptions{Unicast: true}, ErrMACNotUnicast},
+		{universalMulticast, MACOptions{Multicast: true}, nil},
+		{localMulticast, MACOptions{Multicast: true}, nil},
+		{localUnicast, MACOptions{Multicast: true}, ErrMACNotMulticast},
+
+		// The U/L bit tells locally administered addresses apart.
+		{universalUnicast, MACOptions{Universal: true}, nil},
+		{universalMulticast, MACOptions{Universal: true}, nil},
+		{localUnicast, MACOptions{Universal: true}, ErrMACNotUniversal},
+		{localUnicast, MACOptions{Local: true}, nil},
+		{localMulticast, MACOptions{Local: true}, nil},
+		{universalUnicast, MACOptions{Local: true}, ErrMACNotLocal},
+
+		{localUnicast, MACOptions{Unicast: true, Local: true}, nil},
+		{localMulticast, MACOptions{Unicast: true, Local: true}, ErrMACNotUnicast},
+		{"02-42-AC-11-00-02", MACOptions{Lengths: []int{6}, Unicast: true, Universal: true}, ErrMACNotUniversal},
+		{"00:00:5e:00:53", MACOptions{}, ErrInvalidMACAddress},
+	}
+	for _, tt := range tests {
+		if err := ValidateMAC(tt.mac, tt.opts); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateMAC(%q, %+v) = %v, want %v", tt.mac, tt.opts, err, tt.want)
+		}
+	}
+
+	validators := []struct {
+		name     string
+		validate func(string) error
+		valid    string
+		invalid  string
+	}{
+		{"ValidateMAC48", ValidateMAC48, universalUnicast, eui64},
+		{"ValidateEUI64", ValidateEUI64, eui64, universalUnicast},
+		{"ValidateUnicastMAC", ValidateUnicastMAC, localUnicast, universalMulticast},
+		{"ValidateMulticastMAC", ValidateMulticastMAC, localMulticast, universalUnicast},
+		{"ValidateUniversalMAC", ValidateUniversalMAC, universalMulticast, localUnicast},
+		{"ValidateLocalMAC", ValidateLocalMAC, localMulticast, universalUnicast},
+	}
+	for _, v := range validators {
+		if err := v.validate(v.valid); err != nil {
+			t.Errorf("%s(%q) = %v", v.name, v.valid, err)
+		}
+		if err := v.validate(v.invalid); err == nil {
+			t.Errorf("%s(%q) = nil", v.name, v.invalid)
+		}
+	}
+}
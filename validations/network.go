@@ -2,9 +2,12 @@ package validations
 
 import (
 	"errors"
+	"fmt"
 	"net"
+	"net/netip"
 	"net/url"
 	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -67,6 +70,30 @@ var (
 	ErrInvalidURLEncoded = errors.New("invalid URL-encoded data")
 	// ErrInvalidURNRFC2141 is returned when ValidateURNRFC2141 is given an invalid URN according to RFC 2141.
 	ErrInvalidURNRFC2141 = errors.New("invalid URN according to RFC 2141")
+	// ErrNotPrivateIP is returned when an IP address is not in a private range.
+	ErrNotPrivateIP = errors.New("IP address is not private")
+	// ErrNotLoopbackIP is returned when an IP address is not a loopback address.
+	ErrNotLoopbackIP = errors.New("IP address is not a loopback address")
+	// ErrNotLinkLocalIP is returned when an IP address is not link-local.
+	ErrNotLinkLocalIP = errors.New("IP address is not link-local")
+	// ErrNotMulticastIP is returned when an IP address is not a multicast address.
+	ErrNotMulticastIP = errors.New("IP address is not a multicast address")
+	// ErrNotUnspecifiedIP is returned when an IP address is not the unspecified address.
+	ErrNotUnspecifiedIP = errors.New("IP address is not the unspecified address")
+	// ErrNotDocumentationIP is returned when an IP address is not reserved for documentation.
+	ErrNotDocumentationIP = errors.New("IP address is not reserved for documentation")
+	// ErrNotCGNATIP is returned when an IP address is not in the carrier-grade NAT range.
+	ErrNotCGNATIP = errors.New("IP address is not a carrier-grade NAT address")
+	// ErrNotPublicIP is returned when an IP address is not publicly routable.
+	ErrNotPublicIP = errors.New("IP address is not public")
+	// ErrInvalidCIDR is returned when a network in CIDR notation is invalid.
+	ErrInvalidCIDR = errors.New("invalid CIDR")
+	// ErrCIDRNotAllowed is returned when an address is outside of the allowed networks.
+	ErrCIDRNotAllowed = errors.New("address is not in an allowed network")
+	// ErrOverlappingCIDRs is returned when networks overlap.
+	ErrOverlappingCIDRs = errors.New("networks overlap")
+	// ErrPrefixLength is returned when the prefix length of a network is out of bounds.
+	ErrPrefixLength = errors.New("prefix length out of range")
 )
 
 // ValidateIPAddress validates an IPv4 or IPv6 address.
@@ -130,36 +157,114 @@ func ValidateIPv4Address(ipAddress string) error {
 	return nil
 }
 
-// ValidateMACAddress validates a MAC address.
+// ValidateMACAddress validates a MAC address of any length and format
+// accepted by ValidateMAC.
 func ValidateMACAddress(macAddress string) error {
-	if macAddress == "" {
-		return ErrEmptyMACAddress
-	}
-	macAddressRegex := regexp.MustCompile(`^([0-9A-Fa-f]{2}[:-]){5}([0-9A-Fa-f]{2})$`)
-	if !macAddressRegex.MatchString(macAddress) {
-		return ErrInvalidMACAddress
-	}
-	return nil
+	return ValidateMAC(macAddress, MACOptions{})
 }
 
-// ValidateCIDRv4 validates a CIDRv4 address.
+// ValidateCIDRv4 validates an IPv4 network in CIDR notation, such as
+// "10.0.0.0/8".
 func ValidateCIDRv4(cidr string) error {
-	_, _, err := net.ParseCIDR(cidr)
+	p, err := netip.ParsePrefix(cidr)
 	if err != nil {
 		return ErrInvalidCIDRv4
 	}
+	if !p.Addr().Is4() {
+		return fmt.Errorf("%w: %s is not an IPv4 network", ErrInvalidCIDRv4, cidr)
+	}
 	return nil
 }
 
-// ValidateCIDRv6 validates a CIDRv6 address.
+// ValidateCIDRv6 validates an IPv6 network in CIDR notation, such as
+// "2001:db8::/32".
 func ValidateCIDRv6(cidr string) error {
-	_, _, err := net.ParseCIDR(cidr)
+	p, err := netip.ParsePrefix(cidr)
 	if err != nil {
 		return ErrInvalidCIDRv6
 	}
+	if !p.Addr().Is6() {
+		return fmt.Errorf("%w: %s is not an IPv6 network", ErrInvalidCIDRv6, cidr)
+	}
+	return nil
+}
+
+// ValidateCIDR validates an IPv4 or IPv6 network in CIDR notation.
+func ValidateCIDR(cidr string) error {
+	if _, err := netip.ParsePrefix(cidr); err != nil {
+		return ErrInvalidCIDR
+	}
 	return nil
 }
 
+// ValidateInCIDRs checks that an IP address or a network in CIDR notation
+// is contained in one of networks, given in CIDR notation. Networks that
+// cannot be parsed are reported with ErrInvalidCIDR.
+//
+//	ValidateInCIDRs("10.1.0.0/16", "10.0.0.0/8", "192.168.0.0/16")
+func ValidateInCIDRs(address string, networks ...string) error {
+	prefix, err := parseAddrOrPrefix(address)
+	if err != nil {
+		return err
+	}
+	for _, n := range networks {
+		network, err := netip.ParsePrefix(n)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidCIDR, n)
+		}
+		if network.Bits() <= prefix.Bits() && network.Contains(prefix.Addr()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s", ErrCIDRNotAllowed, address)
+}
+
+// ValidateNoOverlap checks that no two of the networks, given in CIDR
+// notation, overlap.
+func ValidateNoOverlap(cidrs []string) error {
+	prefixes := make([]netip.Prefix, len(cidrs))
+	for i, cidr := range cidrs {
+		p, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidCIDR, cidr)
+		}
+		prefixes[i] = p
+		for j := 0; j < i; j++ {
+			if prefixes[j].Overlaps(p) {
+				return fmt.Errorf("%w: %s and %s", ErrOverlappingCIDRs, cidrs[j], cidr)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidatePrefixLength checks that the prefix length of a network in CIDR
+// notation is between min and max, inclusive.
+func ValidatePrefixLength(cidr string, min, max int) error {
+	p, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidCIDR, cidr)
+	}
+	if p.Bits() < min || p.Bits() > max {
+		return fmt.Errorf("%w: /%d is not between /%d and /%d", ErrPrefixLength, p.Bits(), min, max)
+	}
+	return nil
+}
+
+// parseAddrOrPrefix parses an IP address, as a single address prefix, or a
+// network in CIDR notation.
+func parseAddrOrPrefix(s string) (netip.Prefix, error) {
+	if ip, err := netip.ParseAddr(s); err == nil {
+		ip = ip.WithZone("")
+		return netip.PrefixFrom(ip, ip.BitLen()), nil
+	}
+	p, err := netip.ParsePrefix(s)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("%w: %s", ErrInvalidCIDR, s)
+	}
+	return p, nil
+}
+
 // ValidateDataURL validates a data URL.
 func ValidateDataURL(dataURL string) error {
 	pattern := "^data:[a-z]+/[a-z]+(;[a-z-]+=[a-z-]+)*;base64,[a-zA-Z0-9/+=]+$"
@@ -170,11 +275,50 @@ func ValidateDataURL(dataURL string) error {
 	return nil
 }
 
-// ValidateFQDN validates a fully qualified domain name.
+// ValidateFQDN checks the syntax of a fully qualified domain name, without
+// resolving it: at least two dot separated labels of 1 to 63 letters,
+// digits and hyphens, not starting or ending with a hyphen, at most 253
+// characters in total, and a top level domain that is not all digits. A
+// single trailing dot is allowed. Use LookupFQDN to check that the name
+// exists.
 func ValidateFQDN(fqdn string) error {
-	_, err := net.LookupHost(fqdn)
-	if err != nil {
-		return ErrInvalidFQDN
+	name := strings.TrimSuffix(fqdn, ".")
+	if name == "" {
+		return fmt.Errorf("%w: empty name", ErrInvalidFQDN)
+	}
+	if len(name) > 253 {
+		return fmt.Errorf("%w: name is longer than 253 characters", ErrInvalidFQDN)
+	}
+	labels := strings.Split(name, ".")
+	if len(labels) < 2 {
+		return fmt.Errorf("%w: missing top level domain", ErrInvalidFQDN)
+	}
+	for _, label := range labels {
+		if err := checkLabel(label); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidFQDN, err)
+		}
+	}
+	if strings.Trim(labels[len(labels)-1], "0123456789") == "" {
+		return fmt.Errorf("%w: top level domain is numeric", ErrInvalidFQDN)
+	}
+	return nil
+}
+
+// checkLabel checks the syntax of a DNS label.
+func checkLabel(label string) error {
+	switch {
+	case label == "":
+		return errors.New("empty label")
+	case len(label) > 63:
+		return fmt.Errorf("label %q is longer than 63 characters", label)
+	case label[0] == '-' || label[len(label)-1] == '-':
+		return fmt.Errorf("label %q starts or ends with a hyphen", label)
+	}
+	for i := 0; i < len(label); i++ {
+		c := label[i]
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+			return fmt.Errorf("label %q contains %q", label, c)
+		}
 	}
 	return nil
 }
@@ -189,58 +333,108 @@ func ValidateRFC952(hostname string) error {
 	return nil
 }
 
-// ValidateTCP4Addr checks if the given address is a valid TCPv4 address.
+// ValidateTCP4Addr checks if the given address is a valid TCPv4 address,
+// like ValidateTCPAddr. IPv4-mapped IPv6 addresses are accepted as the
+// IPv4 address they map, and "::" as any address.
 func ValidateTCP4Addr(addr string) error {
-	if _, err := net.ResolveTCPAddr("tcp4", addr); err != nil {
-		return ErrInvalidTCP4Addr
-	}
-	return nil
+	return networkAddr(addr, "tcp4", ErrInvalidTCP4Addr)
 }
 
-// ValidateTCP6Addr checks if the given address is a valid TCPv6 address.
+// ValidateTCP6Addr checks if the given address is a valid TCPv6 address,
+// like ValidateTCPAddr. IPv4 and IPv4-mapped IPv6 addresses are rejected.
 func ValidateTCP6Addr(addr string) error {
-	if _, err := net.ResolveTCPAddr("tcp6", addr); err != nil {
-		return ErrInvalidTCP6Addr
-	}
-	return nil
+	return networkAddr(addr, "tcp6", ErrInvalidTCP6Addr)
 }
 
-// ValidateTCPAddr checks if the given address is a valid TCP address.
+// ValidateTCPAddr checks if the given address is a valid TCP address: an
+// optional IP literal or hostname and a port, which may be empty, 0 or the
+// name of a well-known service such as "http". It never hits DNS.
 func ValidateTCPAddr(addr string) error {
-	if _, err := net.ResolveTCPAddr("tcp", addr); err != nil {
-		return ErrInvalidTCPAddr
-	}
-	return nil
+	return networkAddr(addr, "tcp", ErrInvalidTCPAddr)
 }
 
-// ValidateUDP4Addr checks if the given address is a valid UDPv4 address.
+// ValidateUDP4Addr checks if the given address is a valid UDPv4 address,
+// like ValidateTCP4Addr.
 func ValidateUDP4Addr(addr string) error {
-	if _, err := net.ResolveUDPAddr("udp4", addr); err != nil {
-		return ErrInvalidUDP4Addr
-	}
-	return nil
+	return networkAddr(addr, "udp4", ErrInvalidUDP4Addr)
 }
 
-// ValidateUDP6Addr checks if the given address is a valid UDPv6 address.
+// ValidateUDP6Addr checks if the given address is a valid UDPv6 address,
+// like ValidateTCP6Addr.
 func ValidateUDP6Addr(addr string) error {
-	if _, err := net.ResolveUDPAddr("udp6", addr); err != nil {
-		return ErrInvalidUDP6Addr
-	}
-	return nil
+	return networkAddr(addr, "udp6", ErrInvalidUDP6Addr)
 }
 
-// ValidateUDPAddr checks if the given address is a valid UDP address.
+// ValidateUDPAddr checks if the given address is a valid UDP address, like
+// ValidateTCPAddr.
 func ValidateUDPAddr(addr string) error {
-	if _, err := net.ResolveUDPAddr("udp", addr); err != nil {
-		return ErrInvalidUDPAddr
+	return networkAddr(addr, "udp", ErrInvalidUDPAddr)
+}
+
+// networkAddr checks the address of a TCP or UDP network, accepting what
+// net.Listen and net.Dial accept without resolving names.
+func networkAddr(addr, network string, sentinel error) error {
+	addr, opts, err := resolvable(addr, network)
+	if err == nil {
+		err = ValidateHostPort(addr, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", sentinel, err)
 	}
 	return nil
 }
 
-// ValidateUnixAddr checks if the given address is a valid Unix domain socket address.
+// resolvable rewrites a TCP or UDP address the way net.ResolveTCPAddr
+// reads it before resolving its host: an empty port is 0, a service name
+// is replaced by its port on the network and, on IPv4 networks, IPv4-mapped
+// IPv6 addresses are unmapped and "::" stands for any address. It returns the options of ValidateHostPort
+// for the family of the network.
+func resolvable(addr, network string) (string, HostPortOptions, error) {
+	opts := HostPortOptions{AllowZeroPort: true, AllowEmptyHost: true}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", opts, fmt.Errorf("%w: %q", ErrInvalidHostPort, addr)
+	}
+	switch {
+	case port == "":
+		port = "0"
+	case strings.Trim(port, "0123456789") != "":
+		n, err := LookupServicePort(port, network)
+		if err != nil {
+			return "", opts, err
+		}
+		port = strconv.Itoa(n)
+	}
+	ip, err := netip.ParseAddr(host)
+	switch {
+	case strings.HasSuffix(network, "4"):
+		opts.Family = 4
+		switch {
+		case err == nil && ip.Is4In6():
+			host = ip.Unmap().String()
+		case err == nil && ip.WithZone("") == netip.IPv6Unspecified():
+			host = ""
+		}
+	case strings.HasSuffix(network, "6"):
+		opts.Family = 6
+		if err == nil && ip.Is4In6() {
+			return "", opts, fmt.Errorf("%w: %s", ErrExpectedIPv6Address, host)
+		}
+	}
+	return net.JoinHostPort(host, port), opts, nil
+}
+
+// ValidateUnixAddr checks if the given address is a valid Unix domain socket
+// address: a path, or a name in the abstract namespace starting with "@",
+// that fits in a socket address.
 func ValidateUnixAddr(addr string) error {
-	if _, err := net.ResolveUnixAddr("unix", addr); err != nil {
-		return ErrInvalidUnixAddr
+	switch {
+	case addr == "" || addr == "@":
+		return fmt.Errorf("%w: empty path", ErrInvalidUnixAddr)
+	case len(addr) > maxUnixPath:
+		return fmt.Errorf("%w: longer than %d bytes", ErrInvalidUnixAddr, maxUnixPath)
+	case strings.IndexByte(addr, 0) >= 0:
+		return fmt.Errorf("%w: path contains a NUL byte", ErrInvalidUnixAddr)
 	}
 	return nil
 }
@@ -253,22 +447,27 @@ func ValidateURI(uri string) error {
 	return nil
 }
 
-// ValidateURL checks if the given string is a valid URL.
+// ValidateURL checks if the given string is a valid absolute URL, as
+// ValidateStrictURL does without options.
 func ValidateURL(urlStr string) error {
-	if _, err := url.Parse(urlStr); err != nil {
-		return ErrInvalidURL
-	}
-	return nil
+	return ValidateStrictURL(urlStr, URLOptions{})
 }
 
-// ValidateHTTPURL checks if the given string is a valid HTTP or HTTPS URL.
+// ValidateHTTPURL checks if the given string is a valid HTTP or HTTPS URL
+// with a host.
 func ValidateHTTPURL(urlStr string) error {
-	u, err := url.Parse(urlStr)
+	u, err := parseURIReference(urlStr)
 	if err != nil {
-		return ErrInvalidHTTPURL
+		return fmt.Errorf("%w: %v", ErrInvalidHTTPURL, err)
+	}
+	if !strings.EqualFold(u.scheme, "http") && !strings.EqualFold(u.scheme, "https") {
+		return fmt.Errorf("%w: scheme must be http or https", ErrInvalidHTTPURL)
 	}
-	if u.Scheme != "http" && u.Scheme != "https" {
-		return ErrInvalidHTTPURL
+	if u.host == "" {
+		return fmt.Errorf("%w: missing host", ErrInvalidHTTPURL)
+	}
+	if err := checkURLHost(u.host, u.port); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidHTTPURL, err)
 	}
 	return nil
 }
@@ -288,3 +487,182 @@ func ValidateURNRFC2141(urn string) error {
 	}
 	return nil
 }
+
+var (
+	documentationPrefixes = []netip.Prefix{
+		netip.MustParsePrefix("192.0.2.0/24"),
+		netip.MustParsePrefix("198.51.100.0/24"),
+		netip.MustParsePrefix("203.0.113.0/24"),
+		netip.MustParsePrefix("2001:db8::/32"),
+		netip.MustParsePrefix("3fff::/20"),
+	}
+	cgnatPrefix = netip.MustParsePrefix("100.64.0.0/10")
+	// reservedPrefixes are the special-purpose ranges that are not public
+	// but have no classifier of their own.
+	reservedPrefixes = []netip.Prefix{
+		netip.MustParsePrefix("0.0.0.0/8"),
+		netip.MustParsePrefix("192.0.0.0/24"),
+		netip.MustParsePrefix("198.18.0.0/15"),
+		netip.MustParsePrefix("240.0.0.0/4"),
+		netip.MustParsePrefix("64:ff9b:1::/48"),
+		netip.MustParsePrefix("100::/64"),
+		netip.MustParsePrefix("2001::/32"),    // Teredo
+		netip.MustParsePrefix("2001:2::/48"),  // benchmarking
+		netip.MustParsePrefix("2001:10::/28"), // ORCHID
+		netip.MustParsePrefix("2001:20::/28"), // ORCHIDv2
+		netip.MustParsePrefix("fec0::/10"),    // site-local
+	}
+	// embeddingPrefixes are the IPv6 ranges whose addresses embed an IPv4
+	// address, with the offset of its first byte.
+	embeddingPrefixes = []struct {
+		prefix netip.Prefix
+		offset int
+	}{
+		{netip.MustParsePrefix("64:ff9b::/96"), 12},    // NAT64
+		{netip.MustParsePrefix("::/96"), 12},           // IPv4-compatible
+		{netip.MustParsePrefix("::ffff:0:0:0/96"), 12}, // IPv4-translated
+		{netip.MustParsePrefix("2002::/16"), 2},        // 6to4
+	}
+)
+
+// IsPrivateIP reports whether ip is in a private range: 10.0.0.0/8,
+// 172.16.0.0/12 and 192.168.0.0/16 (RFC 1918) or fc00::/7 (RFC 4193).
+// IPv4-mapped IPv6 addresses are classified as the IPv4 address they hold,
+// as are the other classifiers.
+func IsPrivateIP(ip netip.Addr) bool {
+	return ip.Unmap().IsPrivate()
+}
+
+// IsLoopbackIP reports whether ip is in 127.0.0.0/8 or is ::1.
+func IsLoopbackIP(ip netip.Addr) bool {
+	return ip.Unmap().IsLoopback()
+}
+
+// IsLinkLocalIP reports whether ip is a link-local unicast address, in
+// 169.254.0.0/16 or fe80::/10, or a link-local multicast address, in
+// 224.0.0.0/24 or ff02::/16.
+func IsLinkLocalIP(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	return ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast()
+}
+
+// IsMulticastIP reports whether ip is in 224.0.0.0/4 or ff00::/8.
+func IsMulticastIP(ip netip.Addr) bool {
+	return ip.Unmap().IsMulticast()
+}
+
+// IsUnspecifiedIP reports whether ip is 0.0.0.0 or ::.
+func IsUnspecifiedIP(ip netip.Addr) bool {
+	return ip.Unmap().IsUnspecified()
+}
+
+// IsDocumentationIP reports whether ip is reserved for documentation:
+// 192.0.2.0/24, 198.51.100.0/24, 203.0.113.0/24, 2001:db8::/32 or
+// 3fff::/20.
+func IsDocumentationIP(ip netip.Addr) bool {
+	ip = ip.Unmap().WithZone("")
+	for _, p := range documentationPrefixes {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCGNATIP reports whether ip is in the carrier-grade NAT range,
+// 100.64.0.0/10 (RFC 6598).
+func IsCGNATIP(ip netip.Addr) bool {
+	return cgnatPrefix.Contains(ip.Unmap())
+}
+
+// IsPublicIP reports whether ip is publicly routable: it is not private,
+// loopback, link-local, multicast, unspecified, reserved for
+// documentation, carrier-grade NAT, or in another special-purpose range
+// such as 0.0.0.0/8, 198.18.0.0/15, 240.0.0.0/4, Teredo 2001::/32,
+// benchmarking 2001:2::/48, ORCHID 2001:10::/28 and 2001:20::/28 or
+// site-local fec0::/10. IPv6 addresses embedding an IPv4 address, such as
+// those of the well-known NAT64 prefix 64:ff9b::/96, IPv4-compatible
+// addresses in ::/96 and 6to4 addresses in 2002::/16, are classified as
+// the IPv4 address they embed.
+func IsPublicIP(ip netip.Addr) bool {
+	if !ip.IsValid() {
+		return false
+	}
+	ip = ip.Unmap().WithZone("")
+	for _, e := range embeddingPrefixes {
+		if e.prefix.Contains(ip) {
+			b := ip.As16()
+			ip = netip.AddrFrom4([4]byte(b[e.offset : e.offset+4]))
+			break
+		}
+	}
+	if IsPrivateIP(ip) || IsLoopbackIP(ip) || IsLinkLocalIP(ip) || IsMulticastIP(ip) ||
+		IsUnspecifiedIP(ip) || IsDocumentationIP(ip) || IsCGNATIP(ip) {
+		return false
+	}
+	if ip == netip.AddrFrom4([4]byte{255, 255, 255, 255}) {
+		return false
+	}
+	for _, p := range reservedPrefixes {
+		if p.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidatePrivateIP validates an IP address in a private range.
+func ValidatePrivateIP(ipAddress string) error {
+	return classifyIP(ipAddress, IsPrivateIP, ErrNotPrivateIP)
+}
+
+// ValidateLoopbackIP validates a loopback IP address.
+func ValidateLoopbackIP(ipAddress string) error {
+	return classifyIP(ipAddress, IsLoopbackIP, ErrNotLoopbackIP)
+}
+
+// ValidateLinkLocalIP validates a link-local IP address.
+func ValidateLinkLocalIP(ipAddress string) error {
+	return classifyIP(ipAddress, IsLinkLocalIP, ErrNotLinkLocalIP)
+}
+
+// ValidateMulticastIP validates a multicast IP address.
+func ValidateMulticastIP(ipAddress string) error {
+	return classifyIP(ipAddress, IsMulticastIP, ErrNotMulticastIP)
+}
+
+// ValidateUnspecifiedIP validates the unspecified IP address.
+func ValidateUnspecifiedIP(ipAddress string) error {
+	return classifyIP(ipAddress, IsUnspecifiedIP, ErrNotUnspecifiedIP)
+}
+
+// ValidateDocumentationIP validates an IP address reserved for documentation.
+func ValidateDocumentationIP(ipAddress string) error {
+	return classifyIP(ipAddress, IsDocumentationIP, ErrNotDocumentationIP)
+}
+
+// ValidateCGNATIP validates a carrier-grade NAT IP address.
+func ValidateCGNATIP(ipAddress string) error {
+	return classifyIP(ipAddress, IsCGNATIP, ErrNotCGNATIP)
+}
+
+// ValidatePublicIP validates a publicly routable IP address, rejecting
+// internal addresses as needed to prevent SSRF.
+func ValidatePublicIP(ipAddress string) error {
+	return classifyIP(ipAddress, IsPublicIP, ErrNotPublicIP)
+}
+
+// classifyIP parses ipAddress and checks it with is.
+func classifyIP(ipAddress string, is func(netip.Addr) bool, sentinel error) error {
+	if ipAddress == "" {
+		return ErrEmptyIPAddress
+	}
+	ip, err := netip.ParseAddr(ipAddress)
+	if err != nil {
+		return ErrInvalidIPAddress
+	}
+	if !is(ip) {
+		return fmt.Errorf("%w: %s", sentinel, ipAddress)
+	}
+	return nil
+}
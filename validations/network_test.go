@@ -0,0 +1,136 @@
+package validations
+
+import (
+	"errors"
+	"net/netip"
+	"testing"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"93.184.215.14", true},
+		{"8.8.8.8", true},
+		{"2606:2800:21f:cb07:6820:80da:af6b:8b2c", true},
+		{"2a00:1450:4001:82b::200e", true},
+
+		{"10.0.0.1", false},
+		{"172.16.5.4", false},
+		{"192.168.1.1", false},
+		{"127.0.0.1", false},
+		{"169.254.169.254", false},
+		{"224.0.0.1", false},
+		{"0.0.0.0", false},
+		{"0.1.2.3", false},
+		{"100.64.0.1", false},
+		{"192.0.0.8", false},
+		{"192.0.2.1", false},
+		{"198.18.0.1", false},
+		{"240.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::", false},
+		{"::1", false},
+		{"fe80::1%eth0", false},
+		{"fc00::1", false},
+		{"fec0::1", false},
+		{"ff02::1", false},
+		{"2001:db8::1", false},
+		{"3fff::1", false},
+		{"100::1", false},
+
+		// Benchmarking and ORCHID ranges, next to their public neighbours.
+		{"2001:2::1", false},
+		{"2001:2:0:ffff::1", false},
+		{"2001:3::1", true},
+		{"2001:10::1", false},
+		{"2001:1f:ffff::1", false},
+		{"2001:20::1", false},
+		{"2001:2f:ffff::1", false},
+		{"2001:30::1", true},
+
+		// IPv4-mapped and IPv4-compatible addresses.
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:10.0.0.1", false},
+		{"::ffff:93.184.215.14", true},
+		{"::127.0.0.1", false},
+		{"::93.184.215.14", true},
+		{"::ffff:0:a00:1", false},
+		{"::ffff:0:5db8:d70e", true},
+
+		// 6to4 embeds the IPv4 address after the prefix.
+		{"2002:7f00:1::1", false},
+		{"2002:a00:1::", false},
+		{"2002:5db8:d70e::1", true},
+
+		// NAT64 embeds it in the last 32 bits; the local-use prefix is
+		// never public.
+		{"64:ff9b::127.0.0.1", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"64:ff9b::5db8:d70e", true},
+		{"64:ff9b:1::5db8:d70e", false},
+
+		// Teredo addresses are not public whatever server and client they
+		// hold.
+		{"2001::4136:e378:8000:63bf:3fff:fdd2", false},
+		{"2001:0:5db8:d70e::1", false},
+	}
+	for _, tt := range tests {
+		ip := netip.MustParseAddr(tt.ip)
+		if got := IsPublicIP(ip); got != tt.want {
+			t.Errorf("IsPublicIP(%s) = %v, want %v", tt.ip, got, tt.want)
+		}
+	}
+	if IsPublicIP(netip.Addr{}) {
+		t.Error("IsPublicIP of the zero Addr = true, want false")
+	}
+}
+
+func TestValidatePublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want error
+	}{
+		{"93.184.215.14", nil},
+		{"2001:2::1", ErrNotPublicIP},
+		{"::ffff:192.168.0.1", ErrNotPublicIP},
+		{"", ErrEmptyIPAddress},
+		{"example.com", ErrInvalidIPAddress},
+		{"0x7f000001", ErrInvalidIPAddress},
+	}
+	for _, tt := range tests {
+		if err := ValidatePublicIP(tt.ip); !errors.Is(err, tt.want) {
+			t.Errorf("ValidatePublicIP(%q) = %v, want %v", tt.ip, err, tt.want)
+		}
+	}
+}
+
+func TestIPClassifiers(t *testing.T) {
+	tests := []struct {
+		name string
+		is   func(netip.Addr) bool
+		yes  []string
+		no   []string
+	}{
+		{"IsPrivateIP", IsPrivateIP, []string{"10.1.2.3", "::ffff:172.31.0.1", "fd00::1"}, []string{"172.32.0.1", "8.8.8.8"}},
+		{"IsLoopbackIP", IsLoopbackIP, []string{"127.255.0.1", "::1", "::ffff:127.0.0.1"}, []string{"::2", "128.0.0.1"}},
+		{"IsLinkLocalIP", IsLinkLocalIP, []string{"169.254.1.1", "fe80::1", "224.0.0.251", "ff02::fb"}, []string{"224.0.1.1"}},
+		{"IsMulticastIP", IsMulticastIP, []string{"239.1.1.1", "ff05::2"}, []string{"192.168.0.1"}},
+		{"IsUnspecifiedIP", IsUnspecifiedIP, []string{"0.0.0.0", "::", "::ffff:0.0.0.0"}, []string{"0.0.0.1"}},
+		{"IsDocumentationIP", IsDocumentationIP, []string{"198.51.100.7", "2001:db8::%eth0", "3fff:fff::1"}, []string{"3fff:1000::1"}},
+		{"IsCGNATIP", IsCGNATIP, []string{"100.127.255.255", "::ffff:100.64.0.1"}, []string{"100.128.0.0"}},
+	}
+	for _, tt := range tests {
+		for _, ip := range tt.yes {
+			if !tt.is(netip.MustParseAddr(ip)) {
+				t.Errorf("%s(%s) = false, want true", tt.name, ip)
+			}
+		}
+		for _, ip := range tt.no {
+			if tt.is(netip.MustParseAddr(ip)) {
+				t.Errorf("%s(%s) = true, want false", tt.name, ip)
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package validations
+
+import (
+	"net/netip"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// CollapseSpaces trims the string and replaces every run of white space with a single space.
+func CollapseSpaces(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// StripControl removes control characters, such as NUL or escape sequences, from the string.
+func StripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// NormalizeNFC returns the Unicode Normalization Form C of the string.
+func NormalizeNFC(s string) string {
+	return norm.NFC.String(s)
+}
+
+// NormalizeNFKC returns the Unicode Normalization Form KC of the string.
+func NormalizeNFKC(s string) string {
+	return norm.NFKC.String(s)
+}
+
+// NormalizeIPAddress returns the canonical form of an IP address, such as
+// "2001:db8::1" for "2001:0DB8:0:0:0:0:0:1". Invalid addresses are returned unchanged.
+func NormalizeIPAddress(ipAddress string) string {
+	addr, err := netip.ParseAddr(strings.TrimSpace(ipAddress))
+	if err != nil {
+		return ipAddress
+	}
+	return addr.String()
+}
+
+// NormalizeMACAddress returns the canonical lowercase, colon separated form
+// of a MAC address, so "0000.5E00.5301" becomes "00:00:5e:00:53:01".
+// Invalid addresses are returned unchanged.
+func NormalizeMACAddress(macAddress string) string {
+	hw, err := ParseMAC(strings.TrimSpace(macAddress))
+	if err != nil {
+		return macAddress
+	}
+	return hw.String()
+}
+
+// NormalizeE164PhoneNumber removes the spaces, dots, dashes and parentheses
+// commonly used to format phone numbers and replaces a leading "00" with
+// "+", so "+1 (555) 010-0000" becomes "+15550100000". The result is
+// returned unchanged if it is not a valid E.164 number.
+func NormalizeE164PhoneNumber(phone string) string {
+	cleaned := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '.', '-', '(', ')', '\t':
+			return -1
+		}
+		return r
+	}, phone)
+	if strings.HasPrefix(cleaned, "00") {
+		cleaned = "+" + cleaned[2:]
+	}
+	if IsValidE164PhoneNumber(cleaned) != nil {
+		return phone
+	}
+	return cleaned
+}
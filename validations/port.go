@@ -0,0 +1,248 @@
+package validations
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrPrivilegedPort is returned when a port below 1024 is not allowed.
+	ErrPrivilegedPort = errors.New("port is privileged")
+	// ErrNotEphemeralPort is returned when a port must be in the ephemeral range and is not.
+	ErrNotEphemeralPort = errors.New("port is not in the ephemeral range 49152-65535")
+	// ErrEphemeralPort is returned when a port in the ephemeral range is not allowed.
+	ErrEphemeralPort = errors.New("port is in the ephemeral range 49152-65535")
+	// ErrInvalidServiceName is returned when a service name does not follow RFC 6335.
+	ErrInvalidServiceName = errors.New("invalid service name")
+	// ErrUnknownService is returned when a service name is not a well-known service.
+	ErrUnknownService = errors.New("not a well-known service name")
+	// ErrInvalidListenAddr is returned when a string is not a valid listen address.
+	ErrInvalidListenAddr = errors.New("invalid listen address")
+	// ErrUnknownNetwork is returned when a listen address names an unsupported network.
+	ErrUnknownNetwork = errors.New("unknown network")
+)
+
+const (
+	// MaxPrivilegedPort is the highest port that requires privileges to
+	// listen on, on most systems.
+	MaxPrivilegedPort = 1023
+	// MinEphemeralPort is the lowest port of the dynamic range assigned
+	// by IANA, which systems pick from for outgoing connections.
+	MinEphemeralPort = 49152
+	// maxUnixPath is the length of sun_path on Linux, minus the
+	// terminating NUL.
+	maxUnixPath = 107
+)
+
+// PortOptions tunes ValidatePortNumber and ValidatePort.
+type PortOptions struct {
+	// AllowZero accepts port 0, which lets the system pick a port.
+	AllowZero bool
+	// Unprivileged rejects ports up to MaxPrivilegedPort.
+	Unprivileged bool
+	// Ephemeral requires ports from MinEphemeralPort.
+	Ephemeral bool
+	// NotEphemeral rejects ports from MinEphemeralPort, which may be in
+	// use by outgoing connections.
+	NotEphemeral bool
+}
+
+// ValidatePortNumber checks that port is between 1 and 65535, then
+// applies opts.
+func ValidatePortNumber(port int, opts PortOptions) error {
+	switch {
+	case port < 0 || port > 65535 || port == 0 && !opts.AllowZero:
+		return fmt.Errorf("%w: %d", ErrInvalidPort, port)
+	case port == 0:
+		return nil
+	case opts.Unprivileged && port <= MaxPrivilegedPort:
+		return fmt.Errorf("%w: %d", ErrPrivilegedPort, port)
+	case opts.Ephemeral && port < MinEphemeralPort:
+		return fmt.Errorf("%w: %d", ErrNotEphemeralPort, port)
+	case opts.NotEphemeral && port >= MinEphemeralPort:
+		return fmt.Errorf("%w: %d", ErrEphemeralPort, port)
+	}
+	return nil
+}
+
+// ValidatePort checks that port is a decimal port number, as
+// ValidatePortNumber does.
+func ValidatePort(port string, opts PortOptions) error {
+	if err := checkPort(port, true); err != nil {
+		return err
+	}
+	n, _ := strconv.Atoi(port)
+	return ValidatePortNumber(n, opts)
+}
+
+// ValidateServiceName checks that name is the name of a well-known
+// service, such as "http" or "postgresql", or a common alias, such as
+// "postgres". The services are the ones of the /etc/services file of
+// common systems, a subset of the IANA registry, so valid names of less
+// common services are reported with ErrUnknownService. Names are matched
+// without regard to case.
+func ValidateServiceName(name string) error {
+	if _, ok := lookupService(name, ""); ok {
+		return nil
+	}
+	if err := checkServiceName(name); err != nil {
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrUnknownService, name)
+}
+
+// LookupServicePort returns the port assigned to a well-known service for
+// a network, "tcp" or "udp", or for any network when it is empty.
+func LookupServicePort(name, network string) (int, error) {
+	if port, ok := lookupService(name, strings.TrimRight(network, "46")); ok {
+		return port, nil
+	}
+	if err := checkServiceName(name); err != nil {
+		return 0, err
+	}
+	if network != "" {
+		return 0, fmt.Errorf("%w: %s/%s", ErrUnknownService, name, network)
+	}
+	return 0, fmt.Errorf("%w: %s", ErrUnknownService, name)
+}
+
+// ValidateListenAddr checks that addr is an address a server can listen
+// on, optionally prefixed by its network:
+//
+//	:8080
+//	127.0.0.1:http
+//	tcp6://[::1]:8443
+//	udp::53
+//	unix:/var/run/app.sock
+//
+// The host of tcp and udp addresses may be empty and their port may be
+// empty, 0 or a service name, as for ValidateTCPAddr. Without a prefix,
+// the network is tcp.
+func ValidateListenAddr(addr string) error {
+	network, address := "tcp", addr
+	if prefix, rest, ok := strings.Cut(addr, ":"); ok {
+		switch {
+		case isNetwork(prefix):
+			network, address = prefix, strings.TrimPrefix(rest, "//")
+		case strings.HasPrefix(rest, "//"):
+			return fmt.Errorf("%w: %s", ErrUnknownNetwork, prefix)
+		}
+	}
+
+	switch network {
+	case "unix", "unixgram", "unixpacket":
+		if err := ValidateUnixAddr(address); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidListenAddr, err)
+		}
+		return nil
+	}
+	address, opts, err := resolvable(address, network)
+	if err == nil {
+		err = ValidateHostPort(address, opts)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidListenAddr, err)
+	}
+	return nil
+}
+
+// ValidatePortString checks that port is a port number between 1 and
+// 65535.
+func ValidatePortString(port string) error {
+	return ValidatePort(port, PortOptions{})
+}
+
+// ValidateUnprivilegedPort checks that port is a port number above
+// MaxPrivilegedPort.
+func ValidateUnprivilegedPort(port string) error {
+	return ValidatePort(port, PortOptions{Unprivileged: true})
+}
+
+// ValidateEphemeralPort checks that port is a port number from
+// MinEphemeralPort.
+func ValidateEphemeralPort(port string) error {
+	return ValidatePort(port, PortOptions{Ephemeral: true})
+}
+
+// isNetwork reports whether network is supported by ValidateListenAddr.
+func isNetwork(network string) bool {
+	switch network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6", "unix", "unixgram", "unixpacket":
+		return true
+	}
+	return false
+}
+
+// checkServiceName checks the syntax of a service name: 1 to 15 letters,
+// digits and hyphens, with at least one letter and no hyphen at either end
+// or next to another.
+func checkServiceName(name string) error {
+	switch {
+	case name == "" || len(name) > 15:
+		return fmt.Errorf("%w: %q must have 1 to 15 characters", ErrInvalidServiceName, name)
+	case name[0] == '-' || name[len(name)-1] == '-' || strings.Contains(name, "--"):
+		return fmt.Errorf("%w: %q has a misplaced hyphen", ErrInvalidServiceName, name)
+	case strings.Trim(name, "0123456789-") == "":
+		return fmt.Errorf("%w: %q has no letter", ErrInvalidServiceName, name)
+	}
+	for i := 0; i < len(name); i++ {
+		if c := name[i]; !isAlpha(c) && !(c >= '0' && c <= '9') && c != '-' {
+			return fmt.Errorf("%w: %q contains %q", ErrInvalidServiceName, name, c)
+		}
+	}
+	return nil
+}
+
+// servicesData is the table of well-known services, one per line: its
+// name, its port and protocol, and its aliases.
+//
+//go:embed services.dat
+var servicesData string
+
+// serviceKey identifies a service for a protocol.
+type serviceKey struct {
+	name, proto string
+}
+
+var (
+	servicesOnce sync.Once
+	services     map[serviceKey]int
+)
+
+// loadServices parses the embedded table. Services are also registered
+// with an empty protocol, with the port of their first entry.
+func loadServices() {
+	services = make(map[serviceKey]int)
+	for _, line := range strings.Split(servicesData, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(line, "//") {
+			continue
+		}
+		port, proto, ok := strings.Cut(fields[1], "/")
+		n, err := strconv.Atoi(port)
+		if !ok || err != nil {
+			continue
+		}
+		names := append([]string{fields[0]}, fields[2:]...)
+		for _, name := range names {
+			name = strings.ToLower(name)
+			if _, ok := services[serviceKey{name, proto}]; !ok {
+				services[serviceKey{name, proto}] = n
+			}
+			if _, ok := services[serviceKey{name, ""}]; !ok {
+				services[serviceKey{name, ""}] = n
+			}
+		}
+	}
+}
+
+// lookupService returns the port of a service for a protocol.
+func lookupService(name, proto string) (int, bool) {
+	servicesOnce.Do(loadServices)
+	port, ok := services[serviceKey{strings.ToLower(name), proto}]
+	return port, ok
+}
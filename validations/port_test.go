@@ -0,0 +1,166 @@
+package validations
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestValidateListenAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want error
+	}{
+		{":8080", nil},
+		{":0", nil},
+		{":", nil},
+		{"127.0.0.1:8080", nil},
+		{"localhost:8080", nil},
+		{"[::1]:8443", nil},
+		{"127.0.0.1:http", nil},
+		{":HTTPS", nil},
+
+		// Network prefixes, with or without slashes.
+		{"tcp://:8080", nil},
+		{"tcp4://127.0.0.1:80", nil},
+		{"tcp6://[::1]:8443", nil},
+		{"tcp6::8443", nil},
+		{"udp::53", nil},
+		{"udp://0.0.0.0:domain", nil},
+		{"udp6://[::]:ntp", nil},
+		{"unix:/var/run/app.sock", nil},
+		{"unix:///var/run/app.sock", nil},
+		{"unixgram:@app", nil},
+		{"unixpacket:relative.sock", nil},
+
+		{"tcp4://[::1]:80", ErrInvalidListenAddr},
+		{"tcp6://127.0.0.1:80", ErrInvalidListenAddr},
+		{"udp6://[::ffff:127.0.0.1]:53", ErrInvalidListenAddr},
+		{"unix:", ErrInvalidListenAddr},
+		{"unix:/" + strings.Repeat("a", maxUnixPath), ErrInvalidListenAddr},
+		{"8080", ErrInvalidListenAddr},
+		{"::1:8080", ErrInvalidListenAddr},
+		{":65536", ErrInvalidListenAddr},
+		{"exa mple.com:80", ErrInvalidListenAddr},
+
+		// Service names are looked up for the network.
+		{"udp://:http", ErrInvalidListenAddr},
+		{"tcp://:ntp", ErrInvalidListenAddr},
+		{":nosuchservice", ErrInvalidListenAddr},
+		{":-http", ErrInvalidListenAddr},
+
+		// Unknown prefixes followed by slashes are networks, others hosts.
+		{"sctp://:8080", ErrUnknownNetwork},
+		{"http://example.com:80", ErrUnknownNetwork},
+		{"example.com:80", nil},
+	}
+	for _, tt := range tests {
+		if err := ValidateListenAddr(tt.addr); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateListenAddr(%q) = %v, want %v", tt.addr, err, tt.want)
+		}
+	}
+}
+
+func TestLookupServicePort(t *testing.T) {
+	tests := []struct {
+		name, network string
+		want          int
+		err           error
+	}{
+		{"http", "tcp", 80, nil},
+		{"http", "", 80, nil},
+		{"HTTPS", "udp", 443, nil},
+		{"https", "tcp6", 443, nil},
+		{"domain", "udp4", 53, nil},
+		// Aliases resolve to the port of their service.
+		{"www", "tcp", 80, nil},
+		{"postgres", "", 5432, nil},
+		{"krb5", "udp", 88, nil},
+		// As in /etc/services, the first entry of a name wins, aliases
+		// included.
+		{"syslog", "", 514, nil},
+		{"dicom", "tcp", 104, nil},
+		{"gds_db", "tcp", 3050, nil},
+		{"fsp", "", 21, nil},
+
+		{"ntp", "tcp", 0, ErrUnknownService},
+		{"http", "udp", 0, ErrUnknownService},
+		{"fsp", "tcp", 0, ErrUnknownService},
+		{"nosuchservice", "", 0, ErrUnknownService},
+		{"", "tcp", 0, ErrInvalidServiceName},
+		{"80", "tcp", 0, ErrInvalidServiceName},
+		{"a--b", "tcp", 0, ErrInvalidServiceName},
+		{"averylongservicename", "", 0, ErrInvalidServiceName},
+	}
+	for _, tt := range tests {
+		got, err := LookupServicePort(tt.name, tt.network)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("LookupServicePort(%q, %q) = %d, %v, want %d, %v", tt.name, tt.network, got, err, tt.want, tt.err)
+		}
+	}
+	if _, err := LookupServicePort("nosuchservice", "udp"); err == nil || !strings.Contains(err.Error(), "nosuchservice/udp") {
+		t.Errorf("LookupServicePort() = %v, want the network in the error", err)
+	}
+}
+
+func TestValidateServiceName(t *testing.T) {
+	for _, name := range []string{"http", "HTTP", "postgresql", "postgres", "http-alt", "clc-build-daemon"} {
+		if err := ValidateServiceName(name); err != nil {
+			t.Errorf("ValidateServiceName(%q) = %v", name, err)
+		}
+	}
+	tests := []struct {
+		name string
+		want error
+	}{
+		{"my-service", ErrUnknownService},
+		{"", ErrInvalidServiceName},
+		{"-http", ErrInvalidServiceName},
+		{"http-", ErrInvalidServiceName},
+		{"ht--tp", ErrInvalidServiceName},
+		{"8080", ErrInvalidServiceName},
+		{"h.ttp", ErrInvalidServiceName},
+		{"sixteen-letters1", ErrInvalidServiceName},
+	}
+	for _, tt := range tests {
+		if err := ValidateServiceName(tt.name); !errors.Is(err, tt.want) {
+			t.Errorf("ValidateServiceName(%q) = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+// TestServicesData checks the embedded table, whose malformed lines would
+// otherwise be skipped silently.
+func TestServicesData(t *testing.T) {
+	seen := make(map[serviceKey]bool)
+	for i, line := range strings.Split(strings.TrimSuffix(servicesData, "\n"), "\n") {
+		if strings.HasPrefix(line, "//") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			t.Errorf("line %d: %q has no port", i+1, line)
+			continue
+		}
+		port, proto, _ := strings.Cut(fields[1], "/")
+		n, err := strconv.Atoi(port)
+		if err != nil || n < 1 || n > 65535 || proto == "" {
+			t.Errorf("line %d: invalid port %q", i+1, fields[1])
+			continue
+		}
+		for _, name := range append([]string{fields[0]}, fields[2:]...) {
+			key := serviceKey{strings.ToLower(name), proto}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			if got, ok := lookupService(name, proto); !ok || got != n {
+				t.Errorf("line %d: lookupService(%q, %q) = %d, %v, want %d", i+1, name, proto, got, ok, n)
+			}
+		}
+	}
+	if len(seen) < 300 {
+		t.Errorf("loaded %d services, want the common ones", len(seen))
+	}
+}
@@ -0,0 +1,172 @@
+package validations
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var (
+	// ErrPublicSuffix is returned when a domain is a public suffix, such as "co.uk".
+	ErrPublicSuffix = errors.New("domain is a public suffix")
+	// ErrNotRegistrableDomain is returned when a domain is not a registrable domain.
+	ErrNotRegistrableDomain = errors.New("not a registrable domain")
+)
+
+// pslData is the Public Suffix List from https://publicsuffix.org, as
+// written by cmd/pslgen. Run it with a newer copy of the list to update it.
+//
+//go:embed psl.dat
+var pslData string
+
+// pslPrivateMarker starts the rules of the list submitted by the owners of
+// private domains, such as "github.io".
+const pslPrivateMarker = "// ===BEGIN PRIVATE DOMAINS==="
+
+// pslRule describes the rules of the list for a domain.
+type pslRule uint8
+
+const (
+	pslExact     pslRule = 1 << iota // the domain is a public suffix
+	pslWildcard                      // every child of the domain is a public suffix
+	pslException                     // the domain is not a public suffix, despite a wildcard
+	pslPrivate                       // the rule is in the private section
+)
+
+var (
+	pslOnce  sync.Once
+	pslRules map[string]pslRule
+)
+
+// loadPSL parses the embedded list.
+func loadPSL() {
+	pslRules = make(map[string]pslRule, strings.Count(pslData, "\n"))
+	private := false
+	for _, line := range strings.Split(pslData, "\n") {
+		switch {
+		case line == pslPrivateMarker:
+			private = true
+			continue
+		case line == "" || strings.HasPrefix(line, "//"):
+			continue
+		}
+		var kind pslRule
+		switch {
+		case strings.HasPrefix(line, "!"):
+			kind, line = pslException, line[1:]
+		case strings.HasPrefix(line, "*."):
+			kind, line = pslWildcard, line[2:]
+		default:
+			kind = pslExact
+		}
+		if private {
+			kind |= pslPrivate
+		}
+		pslRules[line] |= kind
+	}
+}
+
+// PublicSuffix returns the public suffix of domain, such as "co.uk" for
+// "www.example.co.uk", and reports whether it is managed by ICANN rather
+// than by the owner of a private domain, such as "github.io". Domains
+// matching no rule have their top level domain as public suffix, as the
+// list prescribes. Domains may be in Unicode or ASCII form; the suffix is
+// returned in ASCII form.
+func PublicSuffix(domain string) (suffix string, icann bool, err error) {
+	name, err := pslName(domain)
+	if err != nil {
+		return "", false, err
+	}
+	suffix, icann = publicSuffix(name)
+	return suffix, icann, nil
+}
+
+// IsPublicSuffix reports whether domain is a public suffix, under which
+// anyone can register names, such as "com", "co.uk" or "github.io".
+// Invalid domains are not public suffixes.
+func IsPublicSuffix(domain string) bool {
+	name, err := pslName(domain)
+	if err != nil {
+		return false
+	}
+	suffix, _ := publicSuffix(name)
+	return suffix == name
+}
+
+// EffectiveTLDPlusOne returns the registrable domain of domain: its public
+// suffix and the label before it, such as "example.co.uk" for
+// "www.example.co.uk". Public suffixes have none.
+func EffectiveTLDPlusOne(domain string) (string, error) {
+	name, err := pslName(domain)
+	if err != nil {
+		return "", err
+	}
+	return effectiveTLDPlusOne(name)
+}
+
+// ValidateRegistrableDomain checks that domain is a registrable domain,
+// such as "example.co.uk", rather than a public suffix or a subdomain,
+// for instance to check the domain of a tenant.
+func ValidateRegistrableDomain(domain string) error {
+	name, err := pslName(domain)
+	if err != nil {
+		return err
+	}
+	etld1, err := effectiveTLDPlusOne(name)
+	if err != nil {
+		return err
+	}
+	if name != etld1 {
+		return fmt.Errorf("%w: %s is a subdomain of %s", ErrNotRegistrableDomain, name, etld1)
+	}
+	return nil
+}
+
+// ValidateNotPublicSuffix checks that domain is a registrable domain or a
+// subdomain of one, for instance to check the domain of a cookie.
+func ValidateNotPublicSuffix(domain string) error {
+	_, err := EffectiveTLDPlusOne(domain)
+	return err
+}
+
+// pslName returns the ASCII form of domain, without a trailing dot.
+func pslName(domain string) (string, error) {
+	ascii, err := HostnameToASCII(domain)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(ascii, "."), nil
+}
+
+// publicSuffix returns the public suffix of name, which is in ASCII form.
+func publicSuffix(name string) (suffix string, icann bool) {
+	pslOnce.Do(loadPSL)
+	labels := strings.Split(name, ".")
+	for i := range labels {
+		suffix := strings.Join(labels[i:], ".")
+		if rule := pslRules[suffix]; rule&pslException != 0 {
+			return strings.Join(labels[i+1:], "."), rule&pslPrivate == 0
+		} else if rule&pslExact != 0 {
+			return suffix, rule&pslPrivate == 0
+		}
+		if i+1 < len(labels) {
+			if rule := pslRules[strings.Join(labels[i+1:], ".")]; rule&pslWildcard != 0 {
+				return suffix, rule&pslPrivate == 0
+			}
+		}
+	}
+	return labels[len(labels)-1], false
+}
+
+// effectiveTLDPlusOne returns the registrable domain of name, which is in
+// ASCII form.
+func effectiveTLDPlusOne(name string) (string, error) {
+	suffix, _ := publicSuffix(name)
+	if name == suffix {
+		return "", fmt.Errorf("%w: %s", ErrPublicSuffix, name)
+	}
+	rest := strings.TrimSuffix(name, "."+suffix)
+	return rest[strings.LastIndexByte(rest, '.')+1:] + "." + suffix, nil
+}
@@ -0,0 +1,110 @@
+package validations
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPublicSuffix(t *testing.T) {
+	tests := []struct {
+		domain string
+		suffix string
+		icann  bool
+	}{
+		{"com", "com", true},
+		{"example.com", "com", true},
+		{"www.example.co.uk", "co.uk", true},
+		{"Example.CO.UK.", "co.uk", true},
+		// *.ck makes every child of ck a public suffix, but for !www.ck.
+		{"foo.ck", "foo.ck", true},
+		{"a.foo.ck", "foo.ck", true},
+		{"www.ck", "ck", true},
+		{"a.www.ck", "ck", true},
+		{"city.kawasaki.jp", "kawasaki.jp", true},
+		{"a.b.kawasaki.jp", "b.kawasaki.jp", true},
+		// Private rules are submitted by the owners of the domain.
+		{"github.io", "github.io", false},
+		{"user.github.io", "github.io", false},
+		{"github.com", "com", true},
+		// Domains matching no rule have their TLD as public suffix.
+		{"example.zzz", "zzz", false},
+		{"zzz", "zzz", false},
+		// Unicode domains are converted to ASCII.
+		{"пример.рф", "xn--p1ai", true},
+	}
+	for _, tt := range tests {
+		suffix, icann, err := PublicSuffix(tt.domain)
+		if err != nil || suffix != tt.suffix || icann != tt.icann {
+			t.Errorf("PublicSuffix(%q) = %q, %v, %v, want %q, %v", tt.domain, suffix, icann, err, tt.suffix, tt.icann)
+		}
+	}
+
+	for _, domain := range []string{"", "-bad-.com", "a..com", "exa mple.com"} {
+		if _, _, err := PublicSuffix(domain); err == nil {
+			t.Errorf("PublicSuffix(%q) = nil error", domain)
+		}
+	}
+}
+
+func TestEffectiveTLDPlusOne(t *testing.T) {
+	tests := []struct {
+		domain string
+		want   string
+		err    error
+	}{
+		{"example.com", "example.com", nil},
+		{"www.example.co.uk", "example.co.uk", nil},
+		{"a.b.c.example.com.", "example.com", nil},
+		{"www.ck", "www.ck", nil},
+		{"a.www.ck", "www.ck", nil},
+		{"a.foo.ck", "a.foo.ck", nil},
+		{"user.github.io", "user.github.io", nil},
+		{"a.user.github.io", "user.github.io", nil},
+		{"example.zzz", "example.zzz", nil},
+		{"bücher.example.de", "example.de", nil},
+		{"com", "", ErrPublicSuffix},
+		{"co.uk", "", ErrPublicSuffix},
+		{"foo.ck", "", ErrPublicSuffix},
+		{"github.io", "", ErrPublicSuffix},
+		{"zzz", "", ErrPublicSuffix},
+	}
+	for _, tt := range tests {
+		got, err := EffectiveTLDPlusOne(tt.domain)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("EffectiveTLDPlusOne(%q) = %q, %v, want %q, %v", tt.domain, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+func TestPublicSuffixRules(t *testing.T) {
+	for _, domain := range []string{"com", "co.uk", "foo.ck", "github.io", "xn--p1ai", "рф"} {
+		if !IsPublicSuffix(domain) {
+			t.Errorf("IsPublicSuffix(%q) = false", domain)
+		}
+	}
+	for _, domain := range []string{"example.com", "www.ck", "user.github.io", "", "-bad-"} {
+		if IsPublicSuffix(domain) {
+			t.Errorf("IsPublicSuffix(%q) = true", domain)
+		}
+	}
+
+	tests := []struct {
+		domain      string
+		registrable error
+		notPublic   error
+	}{
+		{"example.co.uk", nil, nil},
+		{"user.github.io", nil, nil},
+		{"www.example.co.uk", ErrNotRegistrableDomain, nil},
+		{"co.uk", ErrPublicSuffix, ErrPublicSuffix},
+		{"github.io", ErrPublicSuffix, ErrPublicSuffix},
+	}
+	for _, tt := range tests {
+		if err := ValidateRegistrableDomain(tt.domain); !errors.Is(err, tt.registrable) {
+			t.Errorf("ValidateRegistrableDomain(%q) = %v, want %v", tt.domain, err, tt.registrable)
+		}
+		if err := ValidateNotPublicSuffix(tt.domain); !errors.Is(err, tt.notPublic) {
+			t.Errorf("ValidateNotPublicSuffix(%q) = %v, want %v", tt.domain, err, tt.notPublic)
+		}
+	}
+}
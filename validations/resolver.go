@@ -0,0 +1,62 @@
+package validations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// ErrFQDNNotFound is returned when a fully qualified domain name does not resolve.
+var ErrFQDNNotFound = errors.New("FQDN does not resolve")
+
+// Resolver looks up the addresses of host names. *net.Resolver implements
+// it; FakeResolver can stand in for it in tests and offline builds.
+type Resolver interface {
+	LookupHost(ctx context.Context, host string) (addrs []string, err error)
+}
+
+// LookupFQDN checks the syntax of fqdn like ValidateFQDN, then checks that
+// it resolves to at least one address with r. A nil r uses
+// net.DefaultResolver. Names that don't exist are reported with
+// ErrFQDNNotFound; other lookup failures, such as timeouts, are returned
+// as is so they are not mistaken for invalid input.
+func LookupFQDN(ctx context.Context, r Resolver, fqdn string) error {
+	if err := ValidateFQDN(fqdn); err != nil {
+		return err
+	}
+	if r == nil {
+		r = net.DefaultResolver
+	}
+	addrs, err := r.LookupHost(ctx, fqdn)
+	var dnsErr *net.DNSError
+	switch {
+	case errors.As(err, &dnsErr) && dnsErr.IsNotFound:
+		return fmt.Errorf("%w: %s", ErrFQDNNotFound, fqdn)
+	case err != nil:
+		return err
+	case len(addrs) == 0:
+		return fmt.Errorf("%w: %s", ErrFQDNNotFound, fqdn)
+	}
+	return nil
+}
+
+// FakeResolver is a Resolver answering from a map of host names to
+// addresses, for tests. Names are matched ignoring case and a trailing
+// dot. Missing names are reported as not found.
+type FakeResolver map[string][]string
+
+// LookupHost returns the addresses of host.
+func (f FakeResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	key := strings.ToLower(strings.TrimSuffix(host, "."))
+	for name, addrs := range f {
+		if strings.ToLower(strings.TrimSuffix(name, ".")) == key {
+			return addrs, nil
+		}
+	}
+	return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+}
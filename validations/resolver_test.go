@@ -0,0 +1,142 @@
+package validations
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+)
+
+// resolverFunc is a Resolver calling a function.
+type resolverFunc func(ctx context.Context, host string) ([]string, error)
+
+func (f resolverFunc) LookupHost(ctx context.Context, host string) ([]string, error) {
+	return f(ctx, host)
+}
+
+// noLookup is a Resolver failing the test when it is used.
+func noLookup(t *testing.T) Resolver {
+	return resolverFunc(func(_ context.Context, host string) ([]string, error) {
+		t.Errorf("unexpected lookup of %s", host)
+		return nil, errors.New("unexpected lookup")
+	})
+}
+
+var hosts = FakeResolver{
+	"example.com":     {"93.184.215.14", "2606:2800:21f:cb07:6820:80da:af6b:8b2c"},
+	"Mixed.Example.":  {"93.184.215.14", "10.0.0.1"},
+	"internal.test":   {"192.168.1.10"},
+	"mapped.test":     {"::ffff:127.0.0.1"},
+	"nat64.test":      {"64:ff9b::a00:1"},
+	"garbage.test":    {"not an address"},
+	"empty.test":      {},
+	"public.nat.test": {"64:ff9b::5db8:d70e"},
+}
+
+func TestLookupFQDN(t *testing.T) {
+	tests := []struct {
+		fqdn string
+		want error
+	}{
+		{"example.com", nil},
+		{"EXAMPLE.com.", nil},
+		{"mixed.example", nil},
+		{"missing.example.com", ErrFQDNNotFound},
+		{"empty.test", ErrFQDNNotFound},
+		{"-bad-.example.com", ErrInvalidFQDN},
+		{"", ErrInvalidFQDN},
+	}
+	for _, tt := range tests {
+		err := LookupFQDN(context.Background(), hosts, tt.fqdn)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("LookupFQDN(%q) = %v, want %v", tt.fqdn, err, tt.want)
+		}
+	}
+}
+
+func TestLookupFQDNFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := LookupFQDN(ctx, hosts, "example.com"); !errors.Is(err, context.Canceled) {
+		t.Errorf("LookupFQDN with a canceled context = %v, want %v", err, context.Canceled)
+	}
+
+	// Failures other than missing names are not reported as such.
+	timeout := &net.DNSError{Err: "i/o timeout", Name: "example.com", IsTimeout: true}
+	r := resolverFunc(func(context.Context, string) ([]string, error) {
+		return nil, timeout
+	})
+	if err := LookupFQDN(context.Background(), r, "example.com"); err != timeout {
+		t.Errorf("LookupFQDN with a timeout = %v, want %v", err, timeout)
+	}
+
+	// Invalid names are not looked up.
+	if err := LookupFQDN(context.Background(), noLookup(t), "exa mple.com"); !errors.Is(err, ErrInvalidFQDN) {
+		t.Errorf("LookupFQDN(%q) = %v, want %v", "exa mple.com", err, ErrInvalidFQDN)
+	}
+}
+
+func TestValidateResolved(t *testing.T) {
+	policy := URLPolicy{Resolver: hosts}
+	tests := []struct {
+		url  string
+		want error
+	}{
+		{"https://example.com/hook", nil},
+		{"https://EXAMPLE.COM./hook", nil},
+		{"https://public.nat.test/", nil},
+		{"https://internal.test/", ErrNotPublicIP},
+		{"https://mixed.example/", ErrNotPublicIP},
+		{"https://mapped.test/", ErrNotPublicIP},
+		{"https://nat64.test/", ErrNotPublicIP},
+		{"https://garbage.test/", ErrNotPublicIP},
+		{"https://empty.test/", ErrFQDNNotFound},
+		{"ftp://example.com/", ErrURLScheme},
+		{"https://localhost/", ErrURLHost},
+	}
+	for _, tt := range tests {
+		err := policy.ValidateResolved(context.Background(), tt.url)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("ValidateResolved(%q) = %v, want %v", tt.url, err, tt.want)
+		}
+	}
+
+	var dnsErr *net.DNSError
+	err := policy.ValidateResolved(context.Background(), "https://missing.example.com/")
+	if !errors.As(err, &dnsErr) || !dnsErr.IsNotFound {
+		t.Errorf("ValidateResolved of a missing host = %v, want a not found error", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := policy.ValidateResolved(ctx, "https://example.com/"); !errors.Is(err, context.Canceled) {
+		t.Errorf("ValidateResolved with a canceled context = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestValidateResolvedSkipsLookup(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy URLPolicy
+		url    string
+		want   error
+	}{
+		{"IP literal", URLPolicy{}, "https://93.184.215.14/", nil},
+		{"private IP literal", URLPolicy{}, "https://10.0.0.1/", ErrNotPublicIP},
+		{"private IPs allowed", URLPolicy{AllowPrivateIPs: true}, "https://internal.test/", nil},
+		{"rejected URL", URLPolicy{Schemes: []string{"https"}}, "http://example.com/", ErrURLScheme},
+		{"denied host", URLPolicy{DeniedHosts: []string{"*.example.com"}}, "https://api.example.com/", ErrURLHost},
+	}
+	for _, tt := range tests {
+		tt.policy.Resolver = noLookup(t)
+		err := tt.policy.ValidateResolved(context.Background(), tt.url)
+		if !errors.Is(err, tt.want) {
+			t.Errorf("%s: ValidateResolved(%q) = %v, want %v", tt.name, tt.url, err, tt.want)
+		}
+	}
+
+	// Without a resolver, names are not looked up.
+	if err := (URLPolicy{}).ValidateResolved(context.Background(), "https://internal.test/"); err != nil {
+		t.Errorf("ValidateResolved without a resolver = %v, want nil", err)
+	}
+}
@@ -0,0 +1,284 @@
+package validations
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrInvalidURIReference is returned when a string is not a valid URI reference.
+	ErrInvalidURIReference = errors.New("invalid URI reference")
+	// ErrURLNoHost is returned when a URL must have a host and does not.
+	ErrURLNoHost = errors.New("URL must have a host")
+	// ErrURLNoPath is returned when a URL must have a path and does not.
+	ErrURLNoPath = errors.New("URL must have a path")
+	// ErrURLFragment is returned when a URL must not have a fragment and does.
+	ErrURLFragment = errors.New("URL must not have a fragment")
+	// ErrURLNotTLS is returned when a URL must use TLS and its scheme does not.
+	ErrURLNotTLS = errors.New("URL must use a TLS scheme")
+)
+
+// URLOptions tunes ValidateStrictURL.
+type URLOptions struct {
+	// RequireHost rejects URLs without an authority or with an empty
+	// host, such as "mailto:a@example.com" or "file:///etc/hosts". The
+	// host must then be an IP literal or a valid hostname.
+	RequireHost bool
+	// RequirePath rejects URLs with an empty path or a path of "/".
+	RequirePath bool
+	// ForbidFragment rejects URLs with a fragment, even an empty one.
+	ForbidFragment bool
+	// RequireTLS rejects schemes that are not secured with TLS, such as
+	// http or ws.
+	RequireTLS bool
+}
+
+// tlsSchemes lists the schemes accepted when URLOptions.RequireTLS is set.
+var tlsSchemes = map[string]bool{
+	"https": true,
+	"wss":   true,
+	"ftps":  true,
+	"ldaps": true,
+	"imaps": true,
+	"smtps": true,
+}
+
+// uriParts holds the components of a URI reference.
+type uriParts struct {
+	scheme    string
+	authority bool
+	host      string
+	port      string
+	path      string
+	fragment  bool
+}
+
+// ValidateStrictURL checks that raw is an absolute URI as defined by
+// RFC 3986: a scheme followed by a hierarchical part, an optional query
+// and an optional fragment, with every character either allowed in its
+// component or percent-encoded. opts adds further requirements.
+//
+//	ValidateStrictURL("https://example.com/hooks", URLOptions{RequireHost: true, RequireTLS: true})
+func ValidateStrictURL(raw string, opts URLOptions) error {
+	u, err := parseURIReference(raw)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+	}
+	if u.scheme == "" {
+		return fmt.Errorf("%w: missing scheme", ErrInvalidURL)
+	}
+	if opts.RequireTLS && !tlsSchemes[strings.ToLower(u.scheme)] {
+		return fmt.Errorf("%w: %s", ErrURLNotTLS, u.scheme)
+	}
+	if opts.RequireHost {
+		if !u.authority || u.host == "" {
+			return ErrURLNoHost
+		}
+		if err := checkURLHost(u.host, u.port); err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidURL, err)
+		}
+	}
+	if opts.RequirePath && (u.path == "" || u.path == "/") {
+		return ErrURLNoPath
+	}
+	if opts.ForbidFragment && u.fragment {
+		return ErrURLFragment
+	}
+	return nil
+}
+
+// ValidateURLWithHost checks that raw is an absolute URL with a host, such
+// as "https://example.com:8443/path".
+func ValidateURLWithHost(raw string) error {
+	return ValidateStrictURL(raw, URLOptions{RequireHost: true})
+}
+
+// ValidateURIReference checks that raw is a URI reference as defined by
+// RFC 3986: an absolute URI or a relative reference such as "../a?b#c".
+// The empty string is a valid reference to the current document.
+func ValidateURIReference(raw string) error {
+	if _, err := parseURIReference(raw); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidURIReference, err)
+	}
+	return nil
+}
+
+// parseURIReference splits raw into its components, following the
+// URI-reference production of RFC 3986.
+func parseURIReference(raw string) (uriParts, error) {
+	var u uriParts
+	if err := checkURIChars(raw); err != nil {
+		return u, err
+	}
+
+	rest := raw
+	if i := strings.IndexByte(rest, '#'); i >= 0 {
+		if strings.ContainsAny(rest[i+1:], "#[]") {
+			return u, errors.New("invalid fragment")
+		}
+		rest, u.fragment = rest[:i], true
+	}
+	if i := strings.IndexByte(rest, '?'); i >= 0 {
+		if strings.ContainsAny(rest[i+1:], "[]") {
+			return u, errors.New("invalid query")
+		}
+		rest = rest[:i]
+	}
+
+	// A colon before the first slash ends the scheme. Relative references
+	// cannot have one in their first segment.
+	if i := strings.IndexByte(rest, ':'); i >= 0 && !strings.Contains(rest[:i], "/") {
+		scheme := rest[:i]
+		if !validScheme(scheme) {
+			return u, fmt.Errorf("invalid scheme %q", scheme)
+		}
+		u.scheme, rest = scheme, rest[i+1:]
+	}
+
+	if authority, ok := strings.CutPrefix(rest, "//"); ok {
+		u.authority = true
+		if i := strings.IndexByte(authority, '/'); i >= 0 {
+			authority, rest = authority[:i], authority[i:]
+		} else {
+			rest = ""
+		}
+		host, port, err := splitAuthority(authority)
+		if err != nil {
+			return u, err
+		}
+		u.host, u.port = host, port
+	}
+	if strings.ContainsAny(rest, "[]") {
+		return u, errors.New("invalid path")
+	}
+	u.path = rest
+	return u, nil
+}
+
+// checkURIChars checks that raw only holds characters allowed somewhere in
+// a URI and that percent signs start a valid percent-encoding.
+func checkURIChars(raw string) error {
+	for i := 0; i < len(raw); i++ {
+		c := raw[i]
+		switch {
+		case c == '%':
+			if i+2 >= len(raw) || !isHexCharacter(raw[i+1]) || !isHexCharacter(raw[i+2]) {
+				return fmt.Errorf("invalid percent-encoding at offset %d", i)
+			}
+			i += 2
+		case isUnreserved(c) || isSubDelim(c) || strings.IndexByte(":/?#[]@", c) >= 0:
+		default:
+			return fmt.Errorf("invalid character %q at offset %d", c, i)
+		}
+	}
+	return nil
+}
+
+// splitAuthority splits an authority into its host and port, checking the
+// syntax of the userinfo, the host and the port.
+func splitAuthority(authority string) (host, port string, err error) {
+	if i := strings.LastIndexByte(authority, '@'); i >= 0 {
+		if strings.ContainsAny(authority[:i], "[]@") {
+			return "", "", errors.New("invalid userinfo")
+		}
+		authority = authority[i+1:]
+	}
+
+	host = authority
+	if strings.HasPrefix(authority, "[") {
+		end := strings.IndexByte(authority, ']')
+		if end < 0 {
+			return "", "", errors.New("unterminated IP literal")
+		}
+		host, authority = authority[:end+1], authority[end+1:]
+		if ip, err := netip.ParseAddr(host[1 : len(host)-1]); (err != nil || !ip.Is6()) && !isIPvFuture(host[1:len(host)-1]) {
+			return "", "", fmt.Errorf("invalid IP literal %s", host)
+		}
+		if authority != "" && authority[0] != ':' {
+			return "", "", fmt.Errorf("unexpected %q after IP literal", authority)
+		}
+	} else if i := strings.LastIndexByte(authority, ':'); i >= 0 {
+		host, authority = authority[:i], authority[i:]
+	} else {
+		authority = ""
+	}
+	if strings.ContainsAny(host[strings.LastIndexByte(host, ']')+1:], "[]:") {
+		return "", "", fmt.Errorf("invalid host %q", host)
+	}
+
+	if authority != "" {
+		port = authority[1:]
+		if strings.Trim(port, "0123456789") != "" {
+			return "", "", fmt.Errorf("invalid port %q", port)
+		}
+	}
+	return host, port, nil
+}
+
+// checkURLHost checks that the host of a URL is an IP literal, an IPv4
+// address or a hostname, and that its port, if any, is in range.
+func checkURLHost(host, port string) error {
+	if port != "" {
+		if n, err := strconv.Atoi(port); err != nil || n > 65535 {
+			return fmt.Errorf("port %s is out of range", port)
+		}
+	}
+	if strings.HasPrefix(host, "[") {
+		return nil
+	}
+	if _, err := netip.ParseAddr(host); err == nil {
+		return nil
+	}
+	return checkHost(host)
+}
+
+// isIPvFuture reports whether s matches the IPvFuture production of
+// RFC 3986, such as "v1.fe80::a+en1".
+func isIPvFuture(s string) bool {
+	if len(s) < 4 || s[0] != 'v' && s[0] != 'V' {
+		return false
+	}
+	version, rest, ok := strings.Cut(s[1:], ".")
+	if !ok || version == "" || rest == "" {
+		return false
+	}
+	for i := 0; i < len(version); i++ {
+		if !isHexCharacter(version[i]) {
+			return false
+		}
+	}
+	for i := 0; i < len(rest); i++ {
+		if c := rest[i]; !isUnreserved(c) && !isSubDelim(c) && c != ':' {
+			return false
+		}
+	}
+	return true
+}
+
+// validScheme reports whether s matches the scheme production of RFC 3986.
+func validScheme(s string) bool {
+	if s == "" || !isAlpha(s[0]) {
+		return false
+	}
+	for i := 1; i < len(s); i++ {
+		if c := s[i]; !isAlpha(c) && !(c >= '0' && c <= '9') && c != '+' && c != '-' && c != '.' {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+func isUnreserved(c byte) bool {
+	return isAlpha(c) || c >= '0' && c <= '9' || c == '-' || c == '.' || c == '_' || c == '~'
+}
+
+func isSubDelim(c byte) bool {
+	return strings.IndexByte("!$&'()*+,;=", c) >= 0
+}
@@ -1,7 +1,22 @@
 package validator
 
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
 // ValidatorImpl is the default implementation of the Validator interface.
 type ValidatorImpl struct {
+	mu    sync.RWMutex
+	rules map[string]Rule
+	cache sync.Map
+
+	ruleSet atomic.Pointer[ruleSetState]
+
+	mutators     map[string]Mutator
+	mutations    map[reflect.Type]map[string][]string
+	mutableTypes atomic.Pointer[sync.Map]
 }
 
 // The Validator interface is implemented by ValidatorImpl.
@@ -12,7 +27,37 @@ func NewValidator() *ValidatorImpl {
 	return &ValidatorImpl{}
 }
 
-// Struct validates the given struct.
+// Struct validates the given struct. When s is a pointer, the mutators of
+// its fields are applied first. Then the validate tags of its fields are
+// checked against the rules of DefaultGroup and, if they pass, the struct's
+// own Validate method is called. Structs implementing GeneratedStruct are
+// only validated by their Validate method, unless the rule set overrides
+// their rules.
 func (v *ValidatorImpl) Struct(s EvaluableStruct) error {
-	return s.Validate()
+	return v.validateStruct(s, Groups{DefaultGroup})
+}
+
+// generated reports whether s implements GeneratedStruct for its own type,
+// rather than through a struct it embeds.
+func generated(s EvaluableStruct) bool {
+	g, ok := s.(GeneratedStruct)
+	return ok && reflect.TypeOf(g.ValidatorGenerated()) == reflect.PointerTo(derefType(reflect.TypeOf(s)))
+}
+
+// validateStruct validates s against the rules of groups, passing args to
+// its Validate method.
+func (v *ValidatorImpl) validateStruct(s EvaluableStruct, groups Groups, args ...interface{}) error {
+	if err := v.Normalize(s); err != nil {
+		return err
+	}
+	if generated(s) {
+		if v.overridden(s) {
+			return v.validateFields(s, groups)
+		}
+		return s.Validate(args...)
+	}
+	if err := v.validateFields(s, groups); err != nil {
+		return err
+	}
+	return s.Validate(args...)
 }
@@ -0,0 +1,32 @@
+package validator
+
+import "reflect"
+
+// Var validates a single value, such as an environment variable or a
+// command line flag, against rules written with the syntax of validate
+// tags. A failure is reported as ValidationErrors holding a FieldError
+// without a field path.
+//
+//	err := val.Var(os.Getenv("LISTEN"), "required,tcp_addr")
+func (v *ValidatorImpl) Var(value interface{}, tag string) error {
+	return v.variable(value, tag, nil)
+}
+
+// VarWithValue validates a single value like Var, comparing it with other
+// for cross-value rules such as eqfield and gtfield, whose parameter is
+// then left out. Errors refer to other as "the other value" and never
+// show it, since it may be a secret.
+//
+//	err := val.VarWithValue(confirm, password, "eqfield")
+func (v *ValidatorImpl) VarWithValue(value, other interface{}, tag string) error {
+	rv := reflect.ValueOf(other)
+	return v.variable(value, tag, func(string) reflect.Value { return rv })
+}
+
+// variable validates a single value against the rules of tag.
+func (v *ValidatorImpl) variable(value interface{}, tag string, other otherFunc) error {
+	if fe := v.checkValue(reflect.ValueOf(value), ParseTag(tag), other); fe != nil {
+		return ValidationErrors{fe}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package validator
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVar(t *testing.T) {
+	v := NewValidator()
+	tests := []struct {
+		value interface{}
+		tag   string
+		want  error
+	}{
+		{"10.0.0.1", "required,ipv4", nil},
+		{"", "required,ipv4", ErrRequired},
+		{"", "omitempty,ipv4", nil},
+		{"abc", "min=3,max=5", nil},
+		{"ab", "min=3,max=5", ErrMin},
+		{8080, "port", nil},
+		{nil, "omitempty,email", nil},
+		{"x", "aplha", ErrUnknownRule},
+		// Without another value, cross-value rules cannot be checked.
+		{"x", "eqfield", ErrNotApplicable},
+	}
+	for _, tt := range tests {
+		err := v.Var(tt.value, tt.tag)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("Var(%v, %q) = %v, want nil", tt.value, tt.tag, err)
+			}
+			continue
+		}
+		var errs ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || errs[0].Field != "" || !errors.Is(errs[0], tt.want) {
+			t.Errorf("Var(%v, %q) = %v, want a single FieldError wrapping %v", tt.value, tt.tag, err, tt.want)
+		}
+	}
+}
+
+func TestVarWithValue(t *testing.T) {
+	v := NewValidator()
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value, other interface{}
+		tag          string
+		want         error
+	}{
+		{"s3cr3t-P@ss", "s3cr3t-P@ss", "required,eqfield", nil},
+		{"s3cr3t-P@sS", "s3cr3t-P@ss", "required,eqfield", ErrEq},
+		{"new", "old", "nefield", nil},
+		{"old", "old", "nefield", ErrNe},
+		{10, 5, "gtfield", nil},
+		{5, 5, "gtfield", ErrGt},
+		{5, 5, "gtefield", nil},
+		{4, 5, "ltfield", nil},
+		{6, 5, "ltefield", ErrMax},
+		{start.Add(time.Hour), start, "gtfield", nil},
+		{start, start.Add(time.Hour), "gtfield", ErrGt},
+		{"", "s3cr3t", "required,eqfield", ErrRequired},
+		{"x", nil, "eqfield", ErrNotApplicable},
+	}
+	for _, tt := range tests {
+		err := v.VarWithValue(tt.value, tt.other, tt.tag)
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("VarWithValue(%v, %v, %q) = %v, want nil", tt.value, tt.other, tt.tag, err)
+			}
+			continue
+		}
+		var errs ValidationErrors
+		if !errors.As(err, &errs) || len(errs) != 1 || !errors.Is(errs[0], tt.want) {
+			t.Errorf("VarWithValue(%v, %v, %q) = %v, want %v", tt.value, tt.other, tt.tag, err, tt.want)
+		}
+	}
+}
+
+func TestVarWithValueHidesOtherValue(t *testing.T) {
+	v := NewValidator()
+	const password = "s3cr3t-P@ss"
+	err := v.VarWithValue("s3cr3t-P@sS", password, "eqfield")
+	if err == nil {
+		t.Fatal("VarWithValue() = nil, want an error")
+	}
+	if msg := err.Error(); msg != "value must be equal to the other value" {
+		t.Errorf("VarWithValue() = %q, want %q", msg, "value must be equal to the other value")
+	}
+	if strings.Contains(err.Error(), password) {
+		t.Errorf("VarWithValue() = %q, reveals the other value", err)
+	}
+
+	// A parameter names the other value instead.
+	err = v.VarWithValue(1, 2, "gtfield=Minimum")
+	if err == nil || !strings.HasSuffix(err.Error(), "Minimum") || strings.Contains(err.Error(), "2") {
+		t.Errorf("VarWithValue() = %v, want the parameter as label", err)
+	}
+}